@@ -14,6 +14,18 @@ resource "amenesik_beam" "mybeam" {
 		{ path = "tag.Probe", value="memory-free" },
 		{ path = "tag.Probe", value="disk-free" },
 
+		# beam document probes
+		{ path = "probe.1.name", value = "memory-free" },
+		{ path = "probe.1.metric", value = "memory:free" },
+		{ path = "probe.1.condition", value = "gr" },
+		{ path = "probe.1.threshold", value = "0" },
+		{ path = "probe.1.type", value = "OCCISCRIPT" },
+		{ path = "probe.1.behaviour", value = "activity" },
+		{ path = "copy.probe", value = "1" },
+		{ path = "probe.last.name", value = "disk-free" },
+		{ path = "probe.last.metric", value = "disk:free" },
+		{ path = "probe.last.threshold", value = "20" },
+
 		# beam document nodes 1
 		{ path = "node.1.name", value = "hwn1" },
 		{ path = "node.1.type", value = "Compute" },
@@ -28,7 +40,6 @@ resource "amenesik_beam" "mybeam" {
 		{ path = "node.2.type", value = "Database" },
 		{ path = "node.2.db.HOST", value = "localhost:3306:1" },
 		{ path = "node.2.db.USER", value = "myuser" },
-		{ path = "node.2.db.PASS", value = "mypass" },
 		{ path = "node.2.db.BASE", value = "mybase" },
 
 		# beam document nodes 3
@@ -66,4 +77,7 @@ resource "amenesik_beam" "mybeam" {
 		{ path = "relation.node.3.hostname", value="node.7" },
 		{ path = "relation.node.5.hostname", value="node.7" },
 	]
+	secret_data = [
+		{ path = "node.2.db.PASS", value = var.db_password },
+	]
 }
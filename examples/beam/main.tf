@@ -4,7 +4,7 @@ terraform {
       source  = "hashicorp.com/edu/amenesik"
     }
   }
-  required_version = ">= 1.1.0"
+  required_version = ">= 1.11.0"
 }
 
 variable "ace_api_key" {
@@ -13,6 +13,13 @@ variable "ace_api_key" {
 	sensitive = true
 }
 
+variable "db_password" {
+	description = "Password of the database user of the BEAM database node"
+	type = string
+	sensitive = true
+	ephemeral = true
+}
+
 provider "amenesik" {
   apikey   = var.ace_api_key
   account  = "amenesik"
@@ -23,8 +23,7 @@ resource "amenesik_beam" "redhat" {
 }
 
 resource "amenesik_beam" "redhat_mysql" { 
-	depends_on = [ amenesik_beam.redhat ]
-	template = "openabal-rhel9-template"
+	template = amenesik_beam.redhat.model_name
 	program  = "mysql-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -75,8 +74,7 @@ resource "amenesik_beam" "redhat_mysql" {
 }
 
 resource "amenesik_beam" "redhat_mysql_small" { 
-	depends_on = [ amenesik_beam.redhat_mysql ]
-	template = "openabal-rhel9-mysql-template"
+	template = amenesik_beam.redhat_mysql.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -99,8 +97,7 @@ resource "amenesik_beam" "redhat_mysql_small" {
 }
 
 resource "amenesik_beam" "redhat_mysql_medium" { 
-	depends_on = [ amenesik_beam.redhat_mysql ]
-	template = "openabal-rhel9-mysql-template"
+	template = amenesik_beam.redhat_mysql.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -123,8 +120,7 @@ resource "amenesik_beam" "redhat_mysql_medium" {
 }
 
 resource "amenesik_beam" "redhat_mysql_large" { 
-	depends_on = [ amenesik_beam.redhat_mysql ]
-	template = "openabal-rhel9-mysql-template"
+	template = amenesik_beam.redhat_mysql.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -147,8 +143,7 @@ resource "amenesik_beam" "redhat_mysql_large" {
 }
 
 resource "amenesik_beam" "redhat_mysql_huge" { 
-	depends_on = [ amenesik_beam.redhat_mysql ]
-	template = "openabal-rhel9-mysql-template"
+	template = amenesik_beam.redhat_mysql.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -171,8 +166,7 @@ resource "amenesik_beam" "redhat_mysql_huge" {
 }
 
 resource "amenesik_beam" "redhat_mariadb" { 
-	depends_on = [ amenesik_beam.redhat ]
-	template = "openabal-rhel9-template"
+	template = amenesik_beam.redhat.model_name
 	program  = "mariadb-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -215,8 +209,7 @@ resource "amenesik_beam" "redhat_mariadb" {
 }
 
 resource "amenesik_beam" "redhat_mariadb_small" { 
-	depends_on = [ amenesik_beam.redhat_mariadb ]
-	template = "openabal-rhel9-mariadb-template"
+	template = amenesik_beam.redhat_mariadb.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -239,8 +232,7 @@ resource "amenesik_beam" "redhat_mariadb_small" {
 }
 
 resource "amenesik_beam" "redhat_mariadb_medium" { 
-	depends_on = [ amenesik_beam.redhat_mariadb ]
-	template = "openabal-rhel9-mariadb-template"
+	template = amenesik_beam.redhat_mariadb.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -263,8 +255,7 @@ resource "amenesik_beam" "redhat_mariadb_medium" {
 }
 
 resource "amenesik_beam" "redhat_mariadb_large" { 
-	depends_on = [ amenesik_beam.redhat_mariadb ]
-	template = "openabal-rhel9-mariadb-template"
+	template = amenesik_beam.redhat_mariadb.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -287,8 +278,7 @@ resource "amenesik_beam" "redhat_mariadb_large" {
 }
 
 resource "amenesik_beam" "redhat_mariadb_huge" { 
-	depends_on = [ amenesik_beam.redhat_mariadb ]
-	template = "openabal-rhel9-mariadb-template"
+	template = amenesik_beam.redhat_mariadb.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -311,8 +301,7 @@ resource "amenesik_beam" "redhat_mariadb_huge" {
 }
 
 resource "amenesik_beam" "redhat_postgres" { 
-	depends_on = [ amenesik_beam.redhat ]
-	template = "openabal-rhel9-template"
+	template = amenesik_beam.redhat.model_name
 	program  = "postgres-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -359,8 +348,7 @@ resource "amenesik_beam" "redhat_postgres" {
 }
 
 resource "amenesik_beam" "redhat_postgres_small" { 
-	depends_on = [ amenesik_beam.redhat_postgres ]
-	template = "openabal-rhel9-postgres-template"
+	template = amenesik_beam.redhat_postgres.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -383,8 +371,7 @@ resource "amenesik_beam" "redhat_postgres_small" {
 }
 
 resource "amenesik_beam" "redhat_postgres_medium" { 
-	depends_on = [ amenesik_beam.redhat_postgres ]
-	template = "openabal-rhel9-postgres-template"
+	template = amenesik_beam.redhat_postgres.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -407,8 +394,7 @@ resource "amenesik_beam" "redhat_postgres_medium" {
 }
 
 resource "amenesik_beam" "redhat_postgres_large" { 
-	depends_on = [ amenesik_beam.redhat_postgres ]
-	template = "openabal-rhel9-postgres-template"
+	template = amenesik_beam.redhat_postgres.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -431,8 +417,7 @@ resource "amenesik_beam" "redhat_postgres_large" {
 }
 
 resource "amenesik_beam" "redhat_postgres_huge" { 
-	depends_on = [ amenesik_beam.redhat_postgres ]
-	template = "openabal-rhel9-postgres-template"
+	template = amenesik_beam.redhat_postgres.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -30,8 +30,7 @@ resource "amenesik_beam" "ubuntu" {
 }
 
 resource "amenesik_beam" "ubuntu_mysql" { 
-	depends_on = [ amenesik_beam.ubuntu ]
-	template = "openabal-u2004-template"
+	template = amenesik_beam.ubuntu.model_name
 	program  = "mysql-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -74,8 +73,7 @@ resource "amenesik_beam" "ubuntu_mysql" {
 }
 
 resource "amenesik_beam" "ubuntu_mysql_small" { 
-	depends_on = [ amenesik_beam.ubuntu_mysql ]
-	template = "openabal-u2004-mysql-template"
+	template = amenesik_beam.ubuntu_mysql.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -98,8 +96,7 @@ resource "amenesik_beam" "ubuntu_mysql_small" {
 }
 
 resource "amenesik_beam" "ubuntu_mysql_medium" { 
-	depends_on = [ amenesik_beam.ubuntu_mysql ]
-	template = "openabal-u2004-mysql-template"
+	template = amenesik_beam.ubuntu_mysql.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -122,8 +119,7 @@ resource "amenesik_beam" "ubuntu_mysql_medium" {
 }
 
 resource "amenesik_beam" "ubuntu_mysql_large" { 
-	depends_on = [ amenesik_beam.ubuntu_mysql ]
-	template = "openabal-u2004-mysql-template"
+	template = amenesik_beam.ubuntu_mysql.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -146,8 +142,7 @@ resource "amenesik_beam" "ubuntu_mysql_large" {
 }
 
 resource "amenesik_beam" "ubuntu_mysql_huge" { 
-	depends_on = [ amenesik_beam.ubuntu_mysql ]
-	template = "openabal-u2004-mysql-template"
+	template = amenesik_beam.ubuntu_mysql.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -170,8 +165,7 @@ resource "amenesik_beam" "ubuntu_mysql_huge" {
 }
 
 resource "amenesik_beam" "ubuntu_mariadb" { 
-	depends_on = [ amenesik_beam.ubuntu ]
-	template = "openabal-u2004-template"
+	template = amenesik_beam.ubuntu.model_name
 	program  = "mariadb-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -214,8 +208,7 @@ resource "amenesik_beam" "ubuntu_mariadb" {
 }
 
 resource "amenesik_beam" "ubuntu_mariadb_small" { 
-	depends_on = [ amenesik_beam.ubuntu_mariadb ]
-	template = "openabal-u2004-mariadb-template"
+	template = amenesik_beam.ubuntu_mariadb.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -238,8 +231,7 @@ resource "amenesik_beam" "ubuntu_mariadb_small" {
 }
 
 resource "amenesik_beam" "ubuntu_mariadb_medium" { 
-	depends_on = [ amenesik_beam.ubuntu_mariadb ]
-	template = "openabal-u2004-mariadb-template"
+	template = amenesik_beam.ubuntu_mariadb.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -262,8 +254,7 @@ resource "amenesik_beam" "ubuntu_mariadb_medium" {
 }
 
 resource "amenesik_beam" "ubuntu_mariadb_large" { 
-	depends_on = [ amenesik_beam.ubuntu_mariadb ]
-	template = "openabal-u2004-mariadb-template"
+	template = amenesik_beam.ubuntu_mariadb.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -286,8 +277,7 @@ resource "amenesik_beam" "ubuntu_mariadb_large" {
 }
 
 resource "amenesik_beam" "ubuntu_mariadb_huge" { 
-	depends_on = [ amenesik_beam.ubuntu_mariadb ]
-	template = "openabal-u2004-mariadb-template"
+	template = amenesik_beam.ubuntu_mariadb.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -310,8 +300,7 @@ resource "amenesik_beam" "ubuntu_mariadb_huge" {
 }
 
 resource "amenesik_beam" "ubuntu_postgres" { 
-	depends_on = [ amenesik_beam.ubuntu ]
-	template = "openabal-u2004-template"
+	template = amenesik_beam.ubuntu.model_name
 	program  = "postgres-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -358,8 +347,7 @@ resource "amenesik_beam" "ubuntu_postgres" {
 }
 
 resource "amenesik_beam" "ubuntu_postgres_small" { 
-	depends_on = [ amenesik_beam.ubuntu_postgres ]
-	template = "openabal-u2004-postgres-template"
+	template = amenesik_beam.ubuntu_postgres.model_name
 	program  = "small-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -382,8 +370,7 @@ resource "amenesik_beam" "ubuntu_postgres_small" {
 }
 
 resource "amenesik_beam" "ubuntu_postgres_medium" { 
-	depends_on = [ amenesik_beam.ubuntu_postgres ]
-	template = "openabal-u2004-postgres-template"
+	template = amenesik_beam.ubuntu_postgres.model_name
 	program  = "medium-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -406,8 +393,7 @@ resource "amenesik_beam" "ubuntu_postgres_medium" {
 }
 
 resource "amenesik_beam" "ubuntu_postgres_large" { 
-	depends_on = [ amenesik_beam.ubuntu_postgres ]
-	template = "openabal-u2004-postgres-template"
+	template = amenesik_beam.ubuntu_postgres.model_name
 	program  = "large-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -430,8 +416,7 @@ resource "amenesik_beam" "ubuntu_postgres_large" {
 }
 
 resource "amenesik_beam" "ubuntu_postgres_huge" { 
-	depends_on = [ amenesik_beam.ubuntu_postgres ]
-	template = "openabal-u2004-postgres-template"
+	template = amenesik_beam.ubuntu_postgres.model_name
 	program  = "huge-template"
 	domain   = "openabal.com"
 	region   = "any"
@@ -0,0 +1,10 @@
+ephemeral "amenesik_session" "script" {}
+
+resource "terraform_data" "report" {
+  provisioner "local-exec" {
+    command = "./report.sh"
+    environment = {
+      ACE_TOKEN = ephemeral.amenesik_session.script.token
+    }
+  }
+}
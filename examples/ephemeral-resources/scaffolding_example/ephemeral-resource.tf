@@ -1,3 +0,0 @@
-ephemeral "scaffolding_example" "example" {
-  configurable_attribute = "some-value"
-}
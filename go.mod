@@ -9,6 +9,7 @@ require (
 	github.com/hashicorp/terraform-plugin-log v0.9.0
 	github.com/hashicorp/terraform-plugin-testing v1.13.3
 	github.com/hashicorp/terraform-provider-scaffolding-framework v0.0.0-20250819153638-9a3d3db58624
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,110 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &accountDataSource{}
+    _ datasource.DataSourceWithConfigure = &accountDataSource{}
+)
+
+// NewAccountDataSource is a helper function to simplify the provider implementation.
+func NewAccountDataSource() datasource.DataSource {
+    return &accountDataSource{}
+}
+
+// account Data Source is the data source implementation.
+type accountDataSource struct{
+	client *Client
+}
+
+// accountDataSourceModel maps the data source schema data.
+type accountDataSourceModel struct {
+    Account     types.String     `tfsdk:"account"`
+    User        types.String     `tfsdk:"user"`
+    Role        types.String     `tfsdk:"role"`
+    Expires     types.String     `tfsdk:"expires"`
+}
+
+func (d *accountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+// Schema defines the schema for the data source.
+func (d *accountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Describes the account, user and role authenticated by the provider.",
+        Attributes: map[string]schema.Attribute{
+            "account": schema.StringAttribute{
+                Computed: true,
+                Description: "The authenticated provisioning account.",
+            },
+            "user": schema.StringAttribute{
+                Computed: true,
+                Description: "The authenticated user of the account.",
+            },
+            "role": schema.StringAttribute{
+                Computed: true,
+                Description: "The role of the authenticated user.",
+            },
+            "expires": schema.StringAttribute{
+                Computed: true,
+                Description: "The expiry of the authentication token as returned by ACE.",
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *accountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state accountDataSourceModel
+    tflog.Info(ctx,"AMENESIK:ACCOUNT ENTER:READ");
+
+    session, err := d.client.Session(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Amenesik Account Not Authenticated",
+            "The authentication of the provisioning account "+d.client.account+" failed.\n\n"+
+                "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.Account = types.StringValue(session.account)
+    state.User = types.StringValue(session.user)
+    state.Role = types.StringValue(session.role)
+    state.Expires = types.StringValue(session.expires)
+
+    diags := resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:ACCOUNT LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *accountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+func TestAccountDataSource(t *testing.T) {
+	tests := map[string]struct {
+		apikey string
+		error  string
+	}{
+		"authenticated":     {apikey: "apikey"},
+		"not authenticated": {apikey: "wrong", error: "Amenesik Account Not Authenticated"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sim := simulator.New("test", "apikey")
+			sim.Start()
+			defer sim.Close()
+			c, err := NewClient(context.Background(), sim.URL(), "test", test.apikey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp := testDataSourceRead(t, NewAccountDataSource(), c, nil)
+			if got := testDataSourceError(resp); got != test.error {
+				t.Fatalf("expected the error %q, got %q: %v", test.error, got, resp.Diagnostics)
+			}
+			if test.error != "" {
+				return
+			}
+			var state accountDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if state.Account.ValueString() != "test" || state.User.ValueString() != "test" || state.Role.ValueString() != "admin" {
+				t.Errorf("unexpected account %v", state)
+			}
+			if expiry, ok := ParseBeamExpiry(state.Expires.ValueString()); !ok || !expiry.After(time.Now()) {
+				t.Errorf("unexpected expiry %s", state.Expires)
+			}
+		})
+	}
+}
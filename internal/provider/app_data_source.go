@@ -0,0 +1,155 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &appDataSource{}
+    _ datasource.DataSourceWithConfigure = &appDataSource{}
+)
+
+// NewAppDataSource is a helper function to simplify the provider implementation.
+func NewAppDataSource() datasource.DataSource {
+    return &appDataSource{}
+}
+
+// app Data Source is the data source implementation.
+type appDataSource struct{
+	client *Client
+}
+
+// appDataSourceModel maps the data source schema data.
+type appDataSourceModel struct {
+    ID          types.String     `tfsdk:"id"`
+    Template    types.String     `tfsdk:"template"`
+    Program	types.String     `tfsdk:"program"`
+    Domain	types.String     `tfsdk:"domain"`
+    State       types.String     `tfsdk:"state"`
+    Locked      types.Bool       `tfsdk:"locked"`
+    Category	types.String     `tfsdk:"category"`
+    Region	types.String     `tfsdk:"region"`
+    Fqdn	types.String     `tfsdk:"fqdn"`
+}
+
+func (d *appDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+// Schema defines the schema for the data source.
+func (d *appDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Inspects a running application instance without managing it.",
+        Attributes: map[string]schema.Attribute{
+            "template": schema.StringAttribute{
+                Required: true,
+            },
+            "program": schema.StringAttribute{
+                Required: true,
+            },
+            "domain": schema.StringAttribute{
+                Required: true,
+            },
+            "id": schema.StringAttribute{
+                Computed: true,
+                Description: "The instance identifier returned by ACE.",
+            },
+            "state": schema.StringAttribute{
+                Computed: true,
+            },
+            "locked": schema.BoolAttribute{
+                Computed: true,
+            },
+            "category": schema.StringAttribute{
+                Computed: true,
+                Description: "The active provisioning category of the instance.",
+            },
+            "region": schema.StringAttribute{
+                Computed: true,
+                Description: "The active provisioning region of the instance.",
+            },
+            "fqdn": schema.StringAttribute{
+                Computed: true,
+                Description: "The fully qualified endpoint domain name composed of the program and domain.",
+            },
+        },
+    }
+}
+
+// returns the value of an optional instance status field
+func statusField(v string) types.String {
+    if v == "none" {
+        return types.StringValue("")
+    }
+    return types.StringValue(v)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *appDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config appDataSourceModel
+    tflog.Info(ctx,"AMENESIK:APP ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    template := config.Template.String()
+    program  := config.Program.String()
+    domain   := config.Domain.String()
+
+    br, err := d.client.StatusBeamInstance(ctx, template, program, domain)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik App",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+    if br.status == "none" {
+        resp.Diagnostics.AddError(
+            "Amenesik App Not Found",
+            "No instance of "+config.Program.ValueString()+" for the template "+config.Template.ValueString()+" exists in the domain "+config.Domain.ValueString()+".",
+        )
+        return
+    }
+
+    config.ID = statusField(br.result.name)
+    config.State = types.StringValue(br.status)
+    config.Locked = types.BoolValue(isLocked(br.result.lock))
+    config.Category = statusField(br.result.category)
+    config.Region = statusField(br.result.region)
+    config.Fqdn = types.StringValue(config.Program.ValueString()+"."+config.Domain.ValueString())
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:APP LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *appDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testStatusClient returns a client of a server answering the status of
+// the shop instance of the webtemplate.
+func testStatusClient(t *testing.T, status int, body string) *Client {
+	return testClient(t, func(req map[string]string) (int, string) {
+		if req["action"] != "status" || req["subject"] != "beam" || req["template"] != "webtemplate" || req["program"] != "shop" || req["domain"] != "example.com" {
+			return http.StatusBadRequest, "unexpected request"
+		}
+		return status, body
+	})
+}
+
+// testAppDataSourceRead reads the app data source of the shop instance.
+func testAppDataSourceRead(t *testing.T, c *Client) (appDataSourceModel, string) {
+	t.Helper()
+	resp := testDataSourceRead(t, NewAppDataSource(), c, map[string]tftypes.Value{
+		"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+		"program":  tftypes.NewValue(tftypes.String, "shop"),
+		"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+	})
+	var state appDataSourceModel
+	if resp.Diagnostics.HasError() {
+		return state, testDataSourceError(resp)
+	}
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return state, ""
+}
+
+func TestAppDataSource(t *testing.T) {
+	state, err := testAppDataSourceRead(t, testStatusClient(t, http.StatusOK, `{"status":"started","id":"webshop","lock":"yes","provider":"amazonec2","region":"france"}`))
+	if err != "" {
+		t.Fatalf("unexpected error %q", err)
+	}
+	expected := map[string]string{
+		"id":       "webshop",
+		"state":    "started",
+		"category": "amazonec2",
+		"region":   "france",
+		"fqdn":     "shop.example.com",
+	}
+	got := map[string]string{
+		"id":       state.ID.ValueString(),
+		"state":    state.State.ValueString(),
+		"category": state.Category.ValueString(),
+		"region":   state.Region.ValueString(),
+		"fqdn":     state.Fqdn.ValueString(),
+	}
+	for name, value := range expected {
+		if got[name] != value {
+			t.Errorf("expected the %s %q, got %q", name, value, got[name])
+		}
+	}
+	if !state.Locked.ValueBool() {
+		t.Errorf("expected a locked instance")
+	}
+}
+
+func TestAppDataSourceOptionalFields(t *testing.T) {
+	state, err := testAppDataSourceRead(t, testStatusClient(t, http.StatusOK, `{"status":"created"}`))
+	if err != "" {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if state.State.ValueString() != "created" || state.Locked.ValueBool() {
+		t.Errorf("unexpected state %q locked %t", state.State.ValueString(), state.Locked.ValueBool())
+	}
+	for name, value := range map[string]string{"id": state.ID.ValueString(), "category": state.Category.ValueString(), "region": state.Region.ValueString()} {
+		if value != "" {
+			t.Errorf("expected an empty %s, got %q", name, value)
+		}
+	}
+}
+
+func TestAppDataSourceNotFound(t *testing.T) {
+	_, err := testAppDataSourceRead(t, testStatusClient(t, http.StatusOK, `{"status":"none"}`))
+	if err != "Amenesik App Not Found" {
+		t.Errorf("unexpected error %q", err)
+	}
+}
+
+func TestAppDataSourceFailure(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+	}{
+		"server error": {http.StatusInternalServerError, `{"message":"failure"}`},
+		"forbidden":    {http.StatusForbidden, `{"message":"forbidden"}`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := testAppDataSourceRead(t, testStatusClient(t, test.status, test.body))
+			if err != "Unable to Read Amenesik App" {
+				t.Errorf("unexpected error %q", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &appNodesDataSource{}
+    _ datasource.DataSourceWithConfigure = &appNodesDataSource{}
+)
+
+// NewAppNodesDataSource is a helper function to simplify the provider implementation.
+func NewAppNodesDataSource() datasource.DataSource {
+    return &appNodesDataSource{}
+}
+
+// app nodes Data Source is the data source implementation.
+type appNodesDataSource struct{
+	client *Client
+}
+
+// appNodesDataSourceModel maps the data source schema data.
+type appNodesDataSourceModel struct {
+    Template    types.String     `tfsdk:"template"`
+    Program	types.String     `tfsdk:"program"`
+    Domain	types.String     `tfsdk:"domain"`
+    Nodes       []appNodeModel   `tfsdk:"nodes"`
+}
+
+// the app instance node model
+type appNodeModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Type        types.String	`tfsdk:"type"`
+    Base        types.String	`tfsdk:"base"`
+    Hostname    types.String	`tfsdk:"hostname"`
+    PublicIp    types.String	`tfsdk:"public_ip"`
+    PrivateIp   types.String	`tfsdk:"private_ip"`
+    Provider    types.String	`tfsdk:"provider"`
+    Region      types.String	`tfsdk:"region"`
+    Status      types.String	`tfsdk:"status"`
+}
+
+func (d *appNodesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_app_nodes"
+}
+
+// Schema defines the schema for the data source.
+func (d *appNodesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the nodes of a running application instance with their hostnames, addresses and status.",
+        Attributes: map[string]schema.Attribute{
+            "template": schema.StringAttribute{
+                Required: true,
+            },
+            "program": schema.StringAttribute{
+                Required: true,
+            },
+            "domain": schema.StringAttribute{
+                Required: true,
+            },
+            "nodes": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "type": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "base": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The hardware node hosting a software node.",
+                        },
+                        "hostname": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "public_ip": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "private_ip": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "provider": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The provisioning category of the node.",
+                        },
+                        "region": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "status": schema.StringAttribute{
+                            Computed: true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *appNodesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config appNodesDataSourceModel
+    tflog.Info(ctx,"AMENESIK:APP NODES ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    nodes, err := d.client.ListBeamInstanceNodes(ctx, config.Template.String(), config.Program.String(), config.Domain.String())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik App Nodes",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    config.Nodes = []appNodeModel{}
+    for _, n := range nodes {
+        config.Nodes = append(config.Nodes, appNodeModel{
+            Name:      types.StringValue(n.Name),
+            Type:      types.StringValue(n.Type),
+            Base:      types.StringValue(n.Base),
+            Hostname:  types.StringValue(n.Hostname),
+            PublicIp:  types.StringValue(n.PublicIp),
+            PrivateIp: types.StringValue(n.PrivateIp),
+            Provider:  types.StringValue(n.Provider),
+            Region:    types.StringValue(n.Region),
+            Status:    types.StringValue(n.Status),
+        })
+    }
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:APP NODES LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *appNodesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
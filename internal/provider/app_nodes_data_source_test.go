@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAppNodesDataSource(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected []appNodeModel
+		error    string
+	}{
+		"nodes": {
+			status: http.StatusOK,
+			body: `{"status":"200","result":[
+				{"name":"hwn1","type":"Compute","hostname":"web.example.com","public_ip":"1.2.3.4","private_ip":"10.0.0.1","provider":"amazonec2","region":"france","status":"started"},
+				{"name":"swn2","type":"WebServer","base":"hwn1","status":"started"}]}`,
+			expected: []appNodeModel{
+				{
+					Name: types.StringValue("hwn1"), Type: types.StringValue("Compute"), Base: types.StringValue(""),
+					Hostname: types.StringValue("web.example.com"), PublicIp: types.StringValue("1.2.3.4"), PrivateIp: types.StringValue("10.0.0.1"),
+					Provider: types.StringValue("amazonec2"), Region: types.StringValue("france"), Status: types.StringValue("started"),
+				},
+				{
+					Name: types.StringValue("swn2"), Type: types.StringValue("WebServer"), Base: types.StringValue("hwn1"),
+					Hostname: types.StringValue(""), PublicIp: types.StringValue(""), PrivateIp: types.StringValue(""),
+					Provider: types.StringValue(""), Region: types.StringValue(""), Status: types.StringValue("started"),
+				},
+			},
+		},
+		"no nodes": {
+			status:   http.StatusOK,
+			body:     `{"status":"200","result":[]}`,
+			expected: []appNodeModel{},
+		},
+		"unknown instance": {
+			status: http.StatusOK,
+			body:   `{"status":"404","message":"instance not found"}`,
+			error:  "Unable to List Amenesik App Nodes",
+		},
+		"server failure": {
+			status: http.StatusInternalServerError,
+			body:   "failure",
+			error:  "Unable to List Amenesik App Nodes",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(req map[string]string) (int, string) {
+				if req["action"] != "nodes" || req["template"] != "webtemplate" || req["program"] != "shop" || req["domain"] != "example.com" {
+					return http.StatusBadRequest, "unexpected request"
+				}
+				return test.status, test.body
+			})
+			resp := testDataSourceRead(t, NewAppNodesDataSource(), c, map[string]tftypes.Value{
+				"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+				"program":  tftypes.NewValue(tftypes.String, "shop"),
+				"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+			})
+			if got := testDataSourceError(resp); got != test.error {
+				t.Fatalf("expected the error %q, got %q: %v", test.error, got, resp.Diagnostics)
+			}
+			if test.error != "" {
+				return
+			}
+			var state appNodesDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if state.Template.ValueString() != "webtemplate" || !reflect.DeepEqual(state.Nodes, test.expected) {
+				t.Errorf("expected the nodes %v, got %v", test.expected, state.Nodes)
+			}
+		})
+	}
+}
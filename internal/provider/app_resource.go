@@ -3,18 +3,23 @@ package provider
 import (
     "context"
     "fmt"
+    "strings"
     "time"
     "errors"
+    "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
     "github.com/hashicorp/terraform-plugin-framework/types"
     "github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-    _ resource.Resource              = &appResource{}
-    _ resource.ResourceWithConfigure = &appResource{}
+    _ resource.Resource                = &appResource{}
+    _ resource.ResourceWithConfigure   = &appResource{}
+    _ resource.ResourceWithImportState = &appResource{}
 )
 
 // NewAppResource is a helper function to simplify the provider implementation.
@@ -50,6 +55,9 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
         Attributes: map[string]schema.Attribute{
             "id": schema.StringAttribute{
                 Computed: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
             },
             "state": schema.StringAttribute{
                 Computed: true,
@@ -60,31 +68,52 @@ func (r *appResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
             "template": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "program": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "domain": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "region": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "category": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "param": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    requiresReplaceUnlessImported(),
+                },
             },
         },
     }
 }
 
+// the interval between the status inspections of an operation underway
+var statusPollInterval = 3 * time.Second
+
 // ----------------------------------------------
 // WAIT FOR STATUS
 // ----------------------------------------------
@@ -100,7 +129,7 @@ func WaitForStatus(r *appResource, ctx context.Context, br *BeamResponse, t stri
     for br.status == waiting {
         var err  error
         var rr *BeamResponse
-	time.Sleep( 3 * time.Second )
+	time.Sleep( statusPollInterval )
 	// inspect the BEAM instance status
 	rr, err = r.client.StatusBeamInstance(ctx, t, p, d )
 	// signal but tolerate errors
@@ -114,7 +143,7 @@ func WaitForStatus(r *appResource, ctx context.Context, br *BeamResponse, t stri
     if br.status == waited {
         return br, nil
     } else {
-	return nil, errors.New("500")
+	return nil, fmt.Errorf("the instance status is %s rather than %s", br.status, waited)
     }
 }
 
@@ -159,42 +188,48 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
     br, err = r.client.CloneBeamModel(ctx,template, program, domain, region, category )
     if err != nil {
 	tflog.Info(ctx,"AMENESIK:APP ERROR: CLONE BEAM MODEL: "+err.Error());
+        resp.Diagnostics.AddError(
+            "Unable to Create Amenesik App",
+            "Amenesik Client Error: "+err.Error(),
+        )
         return
     }
+    plan.ID = types.StringValue(plan.Template.ValueString()+"/"+plan.Program.ValueString()+"/"+plan.Domain.ValueString())
 
     // CREATE the BEAM instance for the domain and application specific parameters
     br, err = r.client.CreateBeamInstance(ctx,template,program,domain,param )
     if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: CREATE BEAM INSTANCE: "+err.Error());
+	r.createFailed(ctx, resp, &plan, "CREATE BEAM INSTANCE", err)
         return
     }
     // accompany the instance creation operation from creating to created or error
     br, err = WaitForStatus(r,ctx,br,template, program, domain, "creating", "created")
     if  err != nil {
-	    return
+	r.createFailed(ctx, resp, &plan, "CREATE BEAM INSTANCE", err)
+	return
     }
     // START the BEAM instance now
     br, err = r.client.StartBeamInstance(ctx, template, program )
     if err != nil {
-        tflog.Info(ctx,"AMENESIK:APP ERROR: START BEAM INSTANCE: "+err.Error());
+	r.createFailed(ctx, resp, &plan, "START BEAM INSTANCE", err)
         return
     }
     // accompany the instance start operation from creating to starting to started
     br, err = WaitForStatus(r,ctx,br,template, program, domain, "starting", "started")
     if  err != nil {
-	    return
+	r.createFailed(ctx, resp, &plan, "START BEAM INSTANCE", err)
+	return
     }
     // LOCK the BEAM instance to protect against undesired state change
     br, err = r.client.LockBeamInstance(ctx, template, program )
     if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: LOCK BEAM INSTANCE: "+err.Error());
+	r.createFailed(ctx, resp, &plan, "LOCK BEAM INSTANCE", err)
         return
     }
     // prepare the final state description
     if br.status == "200" {
 	    br.status = "locked"
     }
-    plan.ID = types.StringValue(time.Now().Format(time.RFC3339))
     plan.State = types.StringValue(br.status)
     plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
     diags = resp.State.Set(ctx, plan)
@@ -206,15 +241,105 @@ func (r *appResource) Create(ctx context.Context, req resource.CreateRequest, re
     return
 }
 
-// Read refreshes the Terraform state with the latest data.
+// ------------------------------------------------
+// Records the partially created APP RESOURCE in the
+// state, where Terraform marks it as tainted, so that
+// the cloned BEAM model and the instance are deleted
+// by the replacement of the next apply.
+// ------------------------------------------------
+func (r *appResource) createFailed(ctx context.Context, resp *resource.CreateResponse, plan *appResourceModel, phase string, err error) {
+    tflog.Info(ctx,"AMENESIK:APP ERROR: "+phase+": "+err.Error());
+    plan.State = types.StringValue("failed")
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+    resp.Diagnostics.AddError(
+        "Unable to Create Amenesik App",
+        "The "+strings.ToLower(phase)+" operation failed. The partially created app will be replaced by the next apply.\n\n"+
+            "Amenesik Client Error: "+err.Error(),
+    )
+}
+
+// ------------------------------------------------
+// READ APP RESOURCE
+// ------------------------------------------------
+// Refreshes the state of the APP RESOURCE from the
+// status of its BEAM instance, including the region
+// and category in which it is deployed. The APP is
+// removed from the state when the instance no
+// longer exists so that it is created again.
+// ------------------------------------------------
 func (r *appResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state appResourceModel
+    tflog.Info(ctx,"AMENESIK:APP ENTER:READ: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    br, err := r.client.StatusBeamInstance(ctx, state.Template.String(), state.Program.String(), state.Domain.String())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik App",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+    if br.status == "none" {
+        tflog.Info(ctx,"AMENESIK:APP LEAVE:READ: NOT FOUND");
+        resp.State.RemoveResource(ctx)
+        return
+    }
+
+    // the values missing from the status response are left unchanged
+    state.State = types.StringValue(br.status)
+    if isLocked(br.result.lock) {
+        state.State = types.StringValue("locked")
+    }
+    if br.result.region != "" && br.result.region != "none" {
+        state.Region = types.StringValue(br.result.region)
+    }
+    if br.result.category != "" && br.result.category != "none" {
+        state.Category = types.StringValue(br.result.category)
+    }
+    diags = resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:APP LEAVE:READ: SUCCESS");
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+// Every configured value requires the replacement of the app, so that only
+// the computed values are carried forward from the prior state.
 func (r *appResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan, state appResourceModel
+    tflog.Info(ctx,"AMENESIK:APP ENTER:UPDATE: Get Plan");
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    plan.ID = state.ID
+    plan.State = state.State
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags := resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:APP LEAVE:UPDATE: SUCCESS");
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
+// ------------------------------------------------
+// DELETE APP RESOURCE
+// ------------------------------------------------
+// Delete the APP RESOURCE from whichever status its
+// instance has reached, such as after a failed or
+// partial creation, performing those of the following
+// actions that are required:
+//
+// - UNLOCK BEAM INSTANCE allowing state change
+// - STOP   BEAM INSTANCE when started or suspended
+// - DROP   BEAM INSTANCE when created or failed
+// - DELETE BEAM MODEL cloned from the template
+// ------------------------------------------------
 func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
     var err  error
     var state appResourceModel
@@ -232,49 +357,90 @@ func (r *appResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
     var br *BeamResponse
 
-    // UNLOCK the BEAM instance allowing sequence of required state change
-    br, err = r.client.UnLockBeamInstance(ctx, template, program )
+    // inspect the BEAM instance status to determine the required actions
+    br, err = r.client.StatusBeamInstance(ctx, template, program, domain )
     if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: UNLOCK BEAM INSTANCE: "+err.Error());
+	r.deleteFailed(ctx, resp, "STATUS BEAM INSTANCE", err)
         return
     }
-    if br.status == "200" {
-	    br.status = "started"
-    }
+    status := br.status
 
-    // STOP the BEAM instance now
-    br, err = r.client.StopBeamInstance(ctx, template, program )
-    if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: STOP BEAM INSTANCE: "+err.Error());
-        return
+    // UNLOCK the BEAM instance allowing sequence of required state change
+    if status != "none" && isLocked(br.result.lock) {
+        br, err = r.client.UnLockBeamInstance(ctx, template, program )
+        if err != nil {
+	    r.deleteFailed(ctx, resp, "UNLOCK BEAM INSTANCE", err)
+            return
+        }
     }
-    // accompany the instance stop operation from stopping to idle
-    br, err = WaitForStatus(r,ctx,br,template, program, domain, "stopping", "created")
-    if  err != nil {
+
+    if status == "started" || status == "suspended" {
+        // STOP the BEAM instance now
+        br, err = r.client.StopBeamInstance(ctx, template, program )
+        if err != nil {
+	    r.deleteFailed(ctx, resp, "STOP BEAM INSTANCE", err)
+            return
+        }
+        // accompany the instance stop operation from stopping to idle
+        br, err = WaitForStatus(r,ctx,br,template, program, domain, "stopping", "created")
+        if  err != nil {
+	    r.deleteFailed(ctx, resp, "STOP BEAM INSTANCE", err)
 	    return
+        }
+        status = "created"
     }
-    // DROP the BEAM instance now
-    br, err = r.client.DropBeamInstance(ctx, template, program )
-    if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: DELETE BEAM INSTANCE: "+err.Error());
-        return
-    }
-    // accompany the instance stop operation from stopping to idle
-    br, err = WaitForStatus(r,ctx,br,template, program, domain, "deleting", "none")
-    if  err != nil {
+
+    if status == "created" || status == "failed" {
+        // DROP the BEAM instance now
+        br, err = r.client.DropBeamInstance(ctx, template, program )
+        if err != nil {
+	    r.deleteFailed(ctx, resp, "DELETE BEAM INSTANCE", err)
+            return
+        }
+        // accompany the instance drop operation from deleting to none
+        br, err = WaitForStatus(r,ctx,br,template, program, domain, "deleting", "none")
+        if  err != nil {
+	    r.deleteFailed(ctx, resp, "DELETE BEAM INSTANCE", err)
 	    return
+        }
     }
-    // DELETE the BEAM model now
+
+    // DELETE the BEAM model now, which may already have been deleted
     br, err = r.client.DeleteBeamModel(ctx, template, program )
-    if err != nil {
-	tflog.Info(ctx,"AMENESIK:APP ERROR: DELETE BEAM MODEL: "+err.Error());
+    if err != nil && !errors.Is(err, ErrBeamNotFound) {
+	r.deleteFailed(ctx, resp, "DELETE BEAM MODEL", err)
         return
     }
-    br.status = ""
     tflog.Info(ctx,"AMENESIK:APP LEAVE:DELETE: SUCCESS");
     return
 }
 
+// reports the failure of an action of the deletion of the app
+func (r *appResource) deleteFailed(ctx context.Context, resp *resource.DeleteResponse, phase string, err error) {
+    tflog.Info(ctx,"AMENESIK:APP ERROR: "+phase+": "+err.Error());
+    resp.Diagnostics.AddError(
+        "Unable to Delete Amenesik App",
+        "The "+strings.ToLower(phase)+" operation failed.\n\n"+
+            "Amenesik Client Error: "+err.Error(),
+    )
+}
+
+// ImportState imports an existing app by its template/program/domain identifier.
+func (r *appResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    parts := strings.Split(req.ID, "/")
+    if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+        resp.Diagnostics.AddError(
+            "Unexpected Import Identifier",
+            fmt.Sprintf("Expected an import identifier of the form template/program/domain, got: %q", req.ID),
+        )
+        return
+    }
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("template"), parts[0])...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("program"), parts[1])...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[2])...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *appResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
     // Add a nil check when handling ProviderData because Terraform
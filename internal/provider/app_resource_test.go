@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+func TestAccAppResource(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: provider + testAccAppResourceConfig("4:8:16"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("amenesik_app.test", tfjsonpath.New("id"), knownvalue.StringExact("webtemplate/shop/example.com")),
+					statecheck.ExpectKnownValue("amenesik_app.test", tfjsonpath.New("state"), knownvalue.StringExact("locked")),
+					statecheck.ExpectKnownValue("amenesik_app.test", tfjsonpath.New("region"), knownvalue.StringExact("france")),
+				},
+				Check: testAccCheckAppInstance(sim, "webshop", "started", true),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "amenesik_app.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"param", "last_updated"},
+			},
+			// Update testing, any change replacing the app
+			{
+				Config: provider + testAccAppResourceConfig("2:4:8"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_app.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: testAccCheckAppInstance(sim, "webshop", "started", true),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccAppResource_failedCreate(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			// the start of the instance fails after the model and instance were created
+			{
+				PreConfig:   func() { sim.Break("start") },
+				Config:      provider + testAccAppResourceConfig("4:8:16"),
+				ExpectError: regexp.MustCompile(`Unable to Create Amenesik App`),
+			},
+			// the tainted app is replaced by the next apply
+			{
+				Config: provider + testAccAppResourceConfig("4:8:16"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("amenesik_app.test", tfjsonpath.New("state"), knownvalue.StringExact("locked")),
+				},
+				Check: testAccCheckAppInstance(sim, "webshop", "started", true),
+			},
+		},
+	})
+}
+
+func TestAccAppResource_failedRequest(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { sim.Fail("lock", 500) },
+				Config:      provider + testAccAppResourceConfig("4:8:16"),
+				ExpectError: regexp.MustCompile(`Unable to Create Amenesik App`),
+			},
+		},
+	})
+}
+
+func TestAccAppResource_drift(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccAppResourceConfig("4:8:16"),
+			},
+			// the instance moved to another region outside of Terraform
+			{
+				PreConfig: func() {
+					m, _ := sim.Model("webshop")
+					m.Region = "germany"
+					sim.SetModel(m)
+				},
+				Config:             provider + testAccAppResourceConfig("4:8:16"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// the app deleted outside of Terraform is created again
+			{
+				PreConfig: func() { sim.DeleteModel("webshop") },
+				Config:    provider + testAccAppResourceConfig("4:8:16"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_app.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckAppInstance(sim, "webshop", "started", true),
+			},
+		},
+	})
+}
+
+func testAccAppResourceConfig(param string) string {
+	return fmt.Sprintf(`
+resource "amenesik_app" "test" {
+  template = "webtemplate"
+  program  = "shop"
+  domain   = "example.com"
+  category = "amazonec2"
+  region   = "france"
+  param    = %[1]q
+}
+`, param)
+}
+
+// testAccCheckAppInstance verifies the status and lock of the simulated instance of the model.
+func testAccCheckAppInstance(sim *simulator.Simulator, name string, status string, lock bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		m, ok := sim.Model(name)
+		if !ok || m.Instance == nil {
+			return fmt.Errorf("the instance of the model %s does not exist", name)
+		}
+		if m.Instance.Status != status || m.Instance.Lock != lock {
+			return fmt.Errorf("the instance of the model %s is %s, locked %t", name, m.Instance.Status, m.Instance.Lock)
+		}
+		return nil
+	}
+}
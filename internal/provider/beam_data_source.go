@@ -0,0 +1,337 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &beamDataSource{}
+    _ datasource.DataSourceWithConfigure = &beamDataSource{}
+)
+
+// NewBeamDataSource is a helper function to simplify the provider implementation.
+func NewBeamDataSource() datasource.DataSource {
+    return &beamDataSource{}
+}
+
+// beam Data Source is the data source implementation.
+type beamDataSource struct{
+	client *Client
+}
+
+// beamDataSourceModel maps the data source schema data.
+type beamDataSourceModel struct {
+    Template    types.String          `tfsdk:"template"`
+    Program	types.String          `tfsdk:"program"`
+    ModelName   types.String          `tfsdk:"model_name"`
+    ToscaYaml   types.String          `tfsdk:"tosca_yaml"`
+    Tags        []beamPropertyModel   `tfsdk:"tags"`
+    Imports     []types.String        `tfsdk:"imports"`
+    Types       []beamTypeModel       `tfsdk:"types"`
+    Nodes       []beamNodeModel       `tfsdk:"nodes"`
+    Relations   []beamRelationModel   `tfsdk:"relations"`
+    Probes      []beamProbeModel      `tfsdk:"probes"`
+}
+
+// the named BEAM property model
+type beamPropertyModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Value       types.String	`tfsdk:"value"`
+}
+
+// the BEAM local node type model
+type beamTypeModel struct {
+    Name        types.String	    `tfsdk:"name"`
+    Properties  []beamPropertyModel `tfsdk:"properties"`
+}
+
+// the BEAM node capability model
+type beamCapabilityModel struct {
+    Name        types.String	    `tfsdk:"name"`
+    Properties  []beamPropertyModel `tfsdk:"properties"`
+}
+
+// the BEAM node model
+type beamNodeModel struct {
+    Name         types.String	       `tfsdk:"name"`
+    Type         types.String	       `tfsdk:"type"`
+    Description  types.String	       `tfsdk:"description"`
+    Base         types.String	       `tfsdk:"base"`
+    Properties   []beamPropertyModel   `tfsdk:"properties"`
+    Capabilities []beamCapabilityModel `tfsdk:"capabilities"`
+}
+
+// the BEAM relation model
+type beamRelationModel struct {
+    Source      types.String	`tfsdk:"source"`
+    Target      types.String	`tfsdk:"target"`
+    Subject     types.String	`tfsdk:"subject"`
+}
+
+// the BEAM monitoring probe model
+type beamProbeModel struct {
+    Name        types.String	    `tfsdk:"name"`
+    Properties  []beamPropertyModel `tfsdk:"properties"`
+}
+
+func (d *beamDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_beam"
+}
+
+// returns the schema of a computed list of named property values
+func beamPropertiesSchema(description string) schema.ListNestedAttribute {
+    return schema.ListNestedAttribute{
+        Computed: true,
+        Description: description,
+        NestedObject: schema.NestedAttributeObject{
+            Attributes: map[string]schema.Attribute{
+                "name": schema.StringAttribute{
+                    Computed: true,
+                },
+                "value": schema.StringAttribute{
+                    Computed: true,
+                },
+            },
+        },
+    }
+}
+
+// Schema defines the schema for the data source.
+func (d *beamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Reads an existing BEAM model as a structured document without importing it.",
+        Attributes: map[string]schema.Attribute{
+            "template": schema.StringAttribute{
+                Required: true,
+            },
+            "program": schema.StringAttribute{
+                Required: true,
+            },
+            "model_name": schema.StringAttribute{
+                Computed: true,
+                Description: "The name of the BEAM model derived from the template and program.",
+            },
+            "tosca_yaml": schema.StringAttribute{
+                Computed: true,
+                Description: "The TOSCA YAML document of the BEAM model as exported by ACE.",
+            },
+            "tags": beamPropertiesSchema("The document tags."),
+            "imports": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "types": schema.ListNestedAttribute{
+                Computed: true,
+                Description: "The local node types of the document.",
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "properties": beamPropertiesSchema("The properties and operations of the node type."),
+                    },
+                },
+            },
+            "nodes": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "type": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "description": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "base": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The hardware node hosting a software node.",
+                        },
+                        "properties": beamPropertiesSchema("The node level properties."),
+                        "capabilities": schema.ListNestedAttribute{
+                            Computed: true,
+                            NestedObject: schema.NestedAttributeObject{
+                                Attributes: map[string]schema.Attribute{
+                                    "name": schema.StringAttribute{
+                                        Computed: true,
+                                    },
+                                    "properties": beamPropertiesSchema("The capability properties."),
+                                },
+                            },
+                        },
+                    },
+                },
+            },
+            "relations": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "source": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "target": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "subject": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The relation subject, hostname or contract.",
+                        },
+                    },
+                },
+            },
+            "probes": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "properties": beamPropertiesSchema("The probe properties."),
+                    },
+                },
+            },
+        },
+    }
+}
+
+// returns the model of a collection of named property values
+func beamPropertyModels(properties []BeamProperty) []beamPropertyModel {
+    items := []beamPropertyModel{}
+    for _, p := range properties {
+        items = append(items, beamPropertyModel{
+            Name:  types.StringValue(p.Name),
+            Value: types.StringValue(p.Value),
+        })
+    }
+    return items
+}
+
+// sets the structured document attributes from the typed BEAM document
+func (m *beamDataSourceModel) setDocument(doc *BeamDocument) {
+    m.Tags = beamPropertyModels(doc.Tags)
+    m.Imports = []types.String{}
+    for _, i := range doc.Imports {
+        m.Imports = append(m.Imports, types.StringValue(i))
+    }
+    m.Types = []beamTypeModel{}
+    for _, t := range doc.Types {
+        m.Types = append(m.Types, beamTypeModel{
+            Name:       types.StringValue(t.Name),
+            Properties: beamPropertyModels(t.Properties),
+        })
+    }
+    m.Nodes = []beamNodeModel{}
+    for _, n := range doc.Nodes {
+        item := beamNodeModel{
+            Name:         types.StringValue(doc.NodeName(n)),
+            Type:         types.StringValue(n.Type),
+            Description:  types.StringValue(n.Description),
+            Base:         types.StringValue(doc.BaseName(n)),
+            Properties:   []beamPropertyModel{},
+            Capabilities: []beamCapabilityModel{},
+        }
+        for _, c := range n.Capabilities {
+            // the unnamed capability holds the node level properties
+            if c.Name == "" {
+                item.Properties = beamPropertyModels(c.Properties)
+                continue
+            }
+            item.Capabilities = append(item.Capabilities, beamCapabilityModel{
+                Name:       types.StringValue(c.Name),
+                Properties: beamPropertyModels(c.Properties),
+            })
+        }
+        m.Nodes = append(m.Nodes, item)
+    }
+    m.Relations = []beamRelationModel{}
+    for _, r := range doc.Relations {
+        m.Relations = append(m.Relations, beamRelationModel{
+            Source:  types.StringValue(doc.NodeName(r.Source)),
+            Target:  types.StringValue(doc.NodeName(r.Target)),
+            Subject: types.StringValue(r.Subject),
+        })
+    }
+    m.Probes = []beamProbeModel{}
+    for _, p := range doc.Probes {
+        m.Probes = append(m.Probes, beamProbeModel{
+            Name:       types.StringValue(p.Name),
+            Properties: beamPropertyModels(p.Properties),
+        })
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *beamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config beamDataSourceModel
+    tflog.Info(ctx,"AMENESIK:BEAM DATA ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    content, err := d.client.ExportBeamModel(ctx, config.Template.String(), config.Program.String())
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik Beam",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    data, err := BeamSourceChanges(content)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Parse Amenesik Beam",
+            "The TOSCA document of the model of the template "+config.Template.ValueString()+" and program "+config.Program.ValueString()+" could not be parsed: "+err.Error(),
+        )
+        return
+    }
+
+    // problems of a model owned elsewhere are reported but never fatal
+    doc, issues := CompileBeamData(data)
+    for _, issue := range issues {
+        resp.Diagnostics.AddWarning(issue.Summary, issue.Detail)
+    }
+
+    config.ModelName = types.StringNull()
+    if name, ok := BeamModelName(config.Template.ValueString(), config.Program.ValueString()); ok {
+        config.ModelName = types.StringValue(name)
+    }
+    config.ToscaYaml = types.StringValue(content)
+    config.setDocument(doc)
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:BEAM DATA LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *beamDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
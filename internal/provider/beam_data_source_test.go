@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// the exported document of the BEAM data source tests
+const testBeamDocument = `tosca_definitions_version: tosca_simple_yaml_1_0
+metadata:
+  Title: Shop
+imports:
+  - Database
+topology_template:
+  node_templates:
+    hwn1:
+      type: tosca.nodes.Compute
+      capabilities:
+        host:
+          properties:
+            num_cpus: 2
+    swn2:
+      type: tosca.nodes.Database
+      properties:
+        port: 3306
+      requirements:
+        - host: hwn1
+    web:
+      requirements:
+        - host: hwn1
+        - connection: relation-1
+  relationship_templates:
+    relation-1:
+      type: tosca.relationships.ConnectsTo
+      properties:
+        source: web
+        target: swn2
+        subject: contract
+`
+
+// testBeamExport returns the response of the export of the document.
+func testBeamExport(document string) string {
+	body, _ := json.Marshal(map[string]string{"status": "200", "result": document})
+	return string(body)
+}
+
+func TestBeamDataSource(t *testing.T) {
+	c := testClient(t, func(req map[string]string) (int, string) {
+		if req["action"] != "export" || req["template"] != "webtemplate" || req["program"] != "shop" {
+			return http.StatusBadRequest, "unexpected request"
+		}
+		return http.StatusOK, testBeamExport(testBeamDocument)
+	})
+	resp := testDataSourceRead(t, NewBeamDataSource(), c, map[string]tftypes.Value{
+		"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+		"program":  tftypes.NewValue(tftypes.String, "shop"),
+	})
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state beamDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if state.ModelName.ValueString() != "webshop" || state.ToscaYaml.ValueString() != testBeamDocument {
+		t.Errorf("unexpected model name %s or document", state.ModelName)
+	}
+	if len(state.Tags) != 1 || state.Tags[0].Name.ValueString() != "Title" || state.Tags[0].Value.ValueString() != "Shop" {
+		t.Errorf("unexpected tags %v", state.Tags)
+	}
+	if got := testStrings(state.Imports); len(got) != 1 || got[0] != "Database" {
+		t.Errorf("unexpected imports %v", got)
+	}
+	if len(state.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %v", state.Nodes)
+	}
+	hwn1, swn2 := state.Nodes[0], state.Nodes[1]
+	if hwn1.Name.ValueString() != "hwn1" || hwn1.Type.ValueString() != "Compute" || len(hwn1.Capabilities) != 1 ||
+		hwn1.Capabilities[0].Name.ValueString() != "host" || hwn1.Capabilities[0].Properties[0].Value.ValueString() != "2" {
+		t.Errorf("unexpected hardware node %v", hwn1)
+	}
+	if swn2.Base.ValueString() != "hwn1" || len(swn2.Properties) != 1 || swn2.Properties[0].Name.ValueString() != "port" || len(swn2.Capabilities) != 0 {
+		t.Errorf("unexpected software node %v", swn2)
+	}
+	if len(state.Relations) != 1 || state.Relations[0].Source.ValueString() != "web" ||
+		state.Relations[0].Target.ValueString() != "swn2" || state.Relations[0].Subject.ValueString() != "contract" {
+		t.Errorf("unexpected relations %v", state.Relations)
+	}
+	if state.Types == nil || state.Probes == nil {
+		t.Errorf("expected empty lists of types and probes")
+	}
+}
+
+func TestBeamDataSourceDocument(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		status   int
+		body     string
+		error    string
+		warnings int
+		named    bool
+	}{
+		"assigned model name": {
+			template: "webbase",
+			status:   http.StatusOK,
+			body:     testBeamExport(testBeamDocument),
+		},
+		"document issues": {
+			template: "webtemplate",
+			status:   http.StatusOK,
+			body:     testBeamExport("topology_template:\n  node_templates:\n    hwn1:\n      type: tosca.nodes.Compute\n"),
+			warnings: 1,
+			named:    true,
+		},
+		"model not found": {
+			template: "webtemplate",
+			status:   http.StatusOK,
+			body:     `{"status":"404","message":"model not found"}`,
+			error:    "Unable to Read Amenesik Beam",
+		},
+		"malformed document": {
+			template: "webtemplate",
+			status:   http.StatusOK,
+			body:     testBeamExport("topology_template: [a,,b]\n"),
+			error:    "Unable to Parse Amenesik Beam",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(map[string]string) (int, string) { return test.status, test.body })
+			resp := testDataSourceRead(t, NewBeamDataSource(), c, map[string]tftypes.Value{
+				"template": tftypes.NewValue(tftypes.String, test.template),
+				"program":  tftypes.NewValue(tftypes.String, "shop"),
+			})
+			if got := testDataSourceError(resp); got != test.error {
+				t.Fatalf("expected the error %q, got %q: %v", test.error, got, resp.Diagnostics)
+			}
+			if test.error != "" {
+				return
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != test.warnings {
+				t.Errorf("expected %d warnings, got %v", test.warnings, resp.Diagnostics)
+			}
+			var state beamDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if state.ModelName.IsNull() == test.named {
+				t.Errorf("unexpected model name %s", state.ModelName)
+			}
+		})
+	}
+}
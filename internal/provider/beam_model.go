@@ -0,0 +1,519 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// BASMATI ENHANCED APPLICATION MODEL (BEAM)
+// -------------------------------------------
+// Local compilation of the BEAM data stream,
+// being the ordered collection of path/value
+// changes of a BEAM resource, into the typed
+// BEAM document model of TAGS, IMPORTS, TYPES
+// NODES, PROBES and RELATIONS.
+// -------------------------------------------
+
+package provider
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// ---------------------------------
+// A single BEAM data stream change
+// ---------------------------------
+type BeamChange struct {
+    Path    string
+    Value   string
+    Unknown bool
+}
+
+// ---------------------------------
+// A named BEAM property value
+// ---------------------------------
+type BeamProperty struct {
+    Name  string
+    Value string
+}
+
+// ---------------------------------
+// A BEAM node capability
+// ---------------------------------
+type BeamCapability struct {
+    Name       string
+    Properties []BeamProperty
+}
+
+// ---------------------------------
+// A BEAM node (hardware or software)
+// ---------------------------------
+type BeamNode struct {
+    Name         string
+    Type         string
+    Description  string
+    Base         string
+    Capabilities []*BeamCapability
+    base         *BeamNode
+    defined      bool
+    typeIndex    int
+}
+
+// ---------------------------------
+// A BEAM monitoring probe
+// ---------------------------------
+type BeamProbe struct {
+    Name       string
+    Properties []BeamProperty
+    defined    bool
+}
+
+// ---------------------------------
+// A BEAM local node type
+// ---------------------------------
+type BeamNodeType struct {
+    Name       string
+    Properties []BeamProperty
+}
+
+// ---------------------------------
+// A BEAM relation between two nodes
+// ---------------------------------
+type BeamRelation struct {
+    Source  *BeamNode
+    Target  *BeamNode
+    Subject string
+}
+
+// ---------------------------------
+// The BEAM document
+// ---------------------------------
+type BeamDocument struct {
+    Tags      []BeamProperty
+    Imports   []string
+    Types     []*BeamNodeType
+    Nodes     []*BeamNode
+    Probes    []*BeamProbe
+    Relations []*BeamRelation
+    // probe tag values naming no probe of the document, which
+    // are expected to name account level probe definitions
+    ProbeRefs []string
+    // the data stream indices of the probe references
+    probeRefIndex []int
+}
+
+// ---------------------------------
+// A BEAM data stream problem report
+// ---------------------------------
+type BeamIssue struct {
+    Index   int
+    Warning bool
+    Summary string
+    Detail  string
+}
+
+// returns the name of a node or its positional identifier when unnamed
+func (d *BeamDocument) NodeName(n *BeamNode) string {
+    if n.Name != "" {
+        return n.Name
+    }
+    for i, item := range d.Nodes {
+        if item == n {
+            return "node-" + strconv.Itoa(i+1)
+        }
+    }
+    return "node"
+}
+
+// returns the name of the base node of a node or the unresolved reference
+func (d *BeamDocument) BaseName(n *BeamNode) string {
+    if n.base != nil {
+        return d.NodeName(n.base)
+    }
+    return n.Base
+}
+
+// returns the value of a named capability property of a node
+func (n *BeamNode) Property(capability string, name string) string {
+    for _, c := range n.Capabilities {
+        if c.Name == capability {
+            return PropertyValue(c.Properties, name)
+        }
+    }
+    return ""
+}
+
+// returns the value of a named property from a property collection
+func PropertyValue(properties []BeamProperty, name string) string {
+    for _, p := range properties {
+        if p.Name == name {
+            return p.Value
+        }
+    }
+    return ""
+}
+
+// returns true when the property may hold multiple values
+func multiValued(name string) bool {
+    return strings.HasSuffix(name, "_port") || strings.HasSuffix(name, "_range")
+}
+
+// sets or appends a property in a property collection
+func setProperty(properties []BeamProperty, name string, value string) []BeamProperty {
+    if !multiValued(name) {
+        for i := range properties {
+            if properties[i].Name == name {
+                properties[i].Value = value
+                return properties
+            }
+        }
+    }
+    return append(properties, BeamProperty{Name: name, Value: value})
+}
+
+// returns a copy of a property collection
+func copyProperties(properties []BeamProperty) []BeamProperty {
+    return append([]BeamProperty(nil), properties...)
+}
+
+// --------------------------------------------------------
+// BEAM PATH
+// --------------------------------------------------------
+// Splits a data path into its dot separated terms, after
+// removal of any white space, such that the path written
+// as "relation . node . 1 . hostname" is also accepted.
+// --------------------------------------------------------
+func BeamPath(p string) []string {
+    return strings.Split(strings.Join(strings.Fields(p), ""), ".")
+}
+
+// --------------------------------------------------------
+// BEAM POSITIONAL PATH
+// --------------------------------------------------------
+// Returns true when the change of the path adds to the BEAM
+// document rather than setting a value, being the probe
+// tags, imports, copies, relations and multi-valued
+// properties, or addresses the last node, probe or type, so
+// that the change depends upon its position in the stream
+// and cannot be applied again without effect.
+// --------------------------------------------------------
+func BeamPositionalPath(p string) bool {
+    terms := BeamPath(p)
+    switch {
+    case terms[0] == "import" || terms[0] == "copy" || terms[0] == "relation":
+        return true
+    case terms[0] == "tag":
+        return len(terms) == 2 && terms[1] == "Probe"
+    }
+    for _, term := range terms {
+        if term == "last" {
+            return true
+        }
+    }
+    return multiValued(terms[len(terms)-1])
+}
+
+// ---------------------------------
+// BEAM data stream compiler state
+// ---------------------------------
+type beamCompiler struct {
+    doc    *BeamDocument
+    issues []BeamIssue
+    index  int
+    limit  int
+}
+
+// the number of template nodes, probes and types that may be addressed
+// beyond those that the data stream could define
+const beamIndexMargin = 64
+
+// returns true when the index of a path may address an element, being
+// bounded by the size of the data stream so that the collections of the
+// document cannot grow without limit
+func (c *beamCompiler) bounded(n int, what string) bool {
+    if n <= c.limit {
+        return true
+    }
+    c.fail("Invalid BEAM Index", fmt.Sprintf("%s addresses the element %d, beyond the %d elements that the data list and template may define.", what, n, c.limit))
+    return false
+}
+
+func (c *beamCompiler) fail(summary string, detail string) {
+    c.issues = append(c.issues, BeamIssue{Index: c.index, Summary: summary, Detail: detail})
+}
+
+func (c *beamCompiler) warn(summary string, detail string) {
+    c.issues = append(c.issues, BeamIssue{Index: c.index, Warning: true, Summary: summary, Detail: detail})
+}
+
+// ---------------------------------------------------------
+// RESOLVE NODE
+// ---------------------------------------------------------
+// Resolves a node identifier, being a number, a node name
+// or the term "last", against the nodes defined so far.
+// Unresolved names are errors. Unresolved numbers are only
+// warnings as the node may be inherited from the template.
+// ---------------------------------------------------------
+func (c *beamCompiler) resolveNode(id string, what string) *BeamNode {
+    nodes := c.doc.Nodes
+    if id == "last" {
+        if len(nodes) == 0 {
+            c.fail("Undefined BEAM Node", what+" refers to the last node but no node has been defined before it.")
+            return nil
+        }
+        return nodes[len(nodes)-1]
+    }
+    if n, err := strconv.Atoi(id); err == nil {
+        if n > 0 && n <= len(nodes) && nodes[n-1].defined {
+            return nodes[n-1]
+        }
+        c.warn("Undefined BEAM Node", fmt.Sprintf("%s refers to node %d which is not defined earlier in the data list. "+
+            "It must be provided by the template.", what, n))
+        return nil
+    }
+    for _, item := range nodes {
+        if item.Name == id {
+            return item
+        }
+    }
+    c.fail("Undefined BEAM Node", fmt.Sprintf("%s refers to the node %q which is not defined earlier in the data list.", what, id))
+    return nil
+}
+
+// locates or creates the node addressed by the identifier of a node path
+func (c *beamCompiler) pathNode(id string, p string) *BeamNode {
+    if n, err := strconv.Atoi(id); err == nil && n > 0 {
+        if !c.bounded(n, "The path "+p) {
+            return nil
+        }
+        for len(c.doc.Nodes) < n {
+            c.doc.Nodes = append(c.doc.Nodes, &BeamNode{})
+        }
+        c.doc.Nodes[n-1].defined = true
+        return c.doc.Nodes[n-1]
+    }
+    return c.resolveNode(id, "The path "+p)
+}
+
+// resolves a probe identifier against the probes defined so far
+func (c *beamCompiler) resolveProbe(id string, what string, create bool) *BeamProbe {
+    probes := c.doc.Probes
+    if id == "last" {
+        if len(probes) == 0 {
+            c.fail("Undefined BEAM Probe", what+" refers to the last probe but no probe has been defined before it.")
+            return nil
+        }
+        return probes[len(probes)-1]
+    }
+    if n, err := strconv.Atoi(id); err == nil && n > 0 {
+        if create {
+            if !c.bounded(n, what) {
+                return nil
+            }
+            for len(c.doc.Probes) < n {
+                c.doc.Probes = append(c.doc.Probes, &BeamProbe{})
+            }
+            c.doc.Probes[n-1].defined = true
+        }
+        if n <= len(c.doc.Probes) && c.doc.Probes[n-1].defined {
+            return c.doc.Probes[n-1]
+        }
+        c.warn("Undefined BEAM Probe", fmt.Sprintf("%s refers to probe %d which is not defined earlier in the data list. "+
+            "It must be provided by the template.", what, n))
+        return nil
+    }
+    for _, item := range probes {
+        if item.Name == id {
+            return item
+        }
+    }
+    c.fail("Undefined BEAM Probe", fmt.Sprintf("%s refers to the probe %q which is not defined earlier in the data list.", what, id))
+    return nil
+}
+
+// locates or creates the node type addressed by a type path
+func (c *beamCompiler) pathType(id string, p string) *BeamNodeType {
+    if n, err := strconv.Atoi(id); err == nil && n > 0 {
+        if !c.bounded(n, "The path "+p) {
+            return nil
+        }
+        for len(c.doc.Types) < n {
+            c.doc.Types = append(c.doc.Types, &BeamNodeType{})
+        }
+        return c.doc.Types[n-1]
+    }
+    if id == "last" && len(c.doc.Types) > 0 {
+        return c.doc.Types[len(c.doc.Types)-1]
+    }
+    for _, item := range c.doc.Types {
+        if item.Name == id {
+            return item
+        }
+    }
+    t := &BeamNodeType{Name: id}
+    c.doc.Types = append(c.doc.Types, t)
+    return t
+}
+
+// the relation target value may be written as "node.<id>" or "<id>"
+func relationTarget(v string) string {
+    terms := BeamPath(v)
+    if len(terms) == 2 && terms[0] == "node" {
+        return terms[1]
+    }
+    return strings.TrimSpace(v)
+}
+
+// --------------------------------------------------------
+// COMPILE BEAM DATA
+// --------------------------------------------------------
+// Applies the ordered BEAM data stream to an empty BEAM
+// document, as would be done by ACE to the cloned model,
+// verifying that the bases, relation sources and targets
+// and copy sources refer to elements that have been defined.
+// Probe tags naming no probe of the document are collected
+// for verification against the account level probes.
+// Entries with unknown values are skipped.
+// --------------------------------------------------------
+func CompileBeamData(data []BeamChange) (*BeamDocument, []BeamIssue) {
+    c := &beamCompiler{doc: &BeamDocument{}, limit: len(data) + beamIndexMargin}
+    var probeTags []int
+    for i, item := range data {
+        c.index = i
+        if item.Unknown {
+            continue
+        }
+        terms := BeamPath(item.Path)
+        value := item.Value
+        switch {
+        case terms[0] == "tag" && len(terms) == 2:
+            if terms[1] == "Probe" {
+                probeTags = append(probeTags, i)
+                c.doc.Tags = append(c.doc.Tags, BeamProperty{Name: terms[1], Value: value})
+            } else {
+                c.doc.Tags = setProperty(c.doc.Tags, terms[1], value)
+            }
+        case terms[0] == "import" && len(terms) == 1:
+            c.doc.Imports = append(c.doc.Imports, value)
+        case terms[0] == "type" && len(terms) == 3:
+            t := c.pathType(terms[1], item.Path)
+            if t == nil {
+                continue
+            }
+            if terms[2] == "name" {
+                t.Name = value
+            } else {
+                t.Properties = setProperty(t.Properties, terms[2], value)
+            }
+        case terms[0] == "copy" && len(terms) == 2 && terms[1] == "node":
+            n := &BeamNode{defined: true}
+            if src := c.resolveNode(value, "The copy.node source"); src != nil {
+                *n = *src
+                n.Capabilities = nil
+                for _, cap := range src.Capabilities {
+                    n.Capabilities = append(n.Capabilities, &BeamCapability{Name: cap.Name, Properties: copyProperties(cap.Properties)})
+                }
+            }
+            n.typeIndex = i
+            c.doc.Nodes = append(c.doc.Nodes, n)
+        case terms[0] == "copy" && len(terms) == 2 && terms[1] == "probe":
+            p := &BeamProbe{defined: true}
+            if src := c.resolveProbe(value, "The copy.probe source", false); src != nil {
+                p.Name = src.Name
+                p.Properties = copyProperties(src.Properties)
+            }
+            c.doc.Probes = append(c.doc.Probes, p)
+        case terms[0] == "probe" && len(terms) == 3:
+            p := c.resolveProbe(terms[1], "The path "+item.Path, true)
+            if p == nil {
+                continue
+            }
+            if terms[2] == "name" {
+                p.Name = value
+            } else {
+                p.Properties = setProperty(p.Properties, terms[2], value)
+            }
+        case terms[0] == "relation" && len(terms) == 4 && terms[1] == "node":
+            r := &BeamRelation{Subject: terms[3]}
+            r.Source = c.resolveNode(terms[2], "The relation source")
+            r.Target = c.resolveNode(relationTarget(value), "The relation target")
+            if r.Subject != "hostname" && r.Subject != "contract" {
+                c.warn("Unexpected BEAM Relation Subject", "The relation subject "+r.Subject+" is neither hostname nor contract.")
+            }
+            if r.Source != nil && r.Target != nil {
+                c.doc.Relations = append(c.doc.Relations, r)
+            }
+        case terms[0] == "node" && (len(terms) == 3 || len(terms) == 4):
+            n := c.pathNode(terms[1], item.Path)
+            if n == nil {
+                continue
+            }
+            if len(terms) == 4 {
+                n.setCapability(terms[2], terms[3], value)
+                continue
+            }
+            switch terms[2] {
+            case "name":
+                n.Name = value
+            case "type":
+                n.Type = value
+                n.typeIndex = i
+            case "description":
+                n.Description = value
+            case "base":
+                n.Base = value
+                n.base = c.resolveNode(value, "The base of "+item.Path)
+            default:
+                n.setCapability("", terms[2], value)
+            }
+        default:
+            c.warn("Unrecognised BEAM Data Path", "The path "+item.Path+" does not address a tag, import, type, node, probe, relation or copy.")
+        }
+    }
+
+    // probe tags are document level and may precede the probe definitions
+    for _, i := range probeTags {
+        if c.doc.probe(data[i].Value) == nil {
+            c.doc.ProbeRefs = append(c.doc.ProbeRefs, data[i].Value)
+            c.doc.probeRefIndex = append(c.doc.probeRefIndex, i)
+        }
+    }
+
+    // hardware nodes are expected to carry at least one software layer
+    for _, n := range c.doc.Nodes {
+        if !strings.EqualFold(n.Type, "Compute") || c.doc.layered(n) {
+            continue
+        }
+        c.index = n.typeIndex
+        c.warn("BEAM Compute Node Without Software", "The Compute node "+c.doc.NodeName(n)+" is not the base of any software node.")
+    }
+    return c.doc, c.issues
+}
+
+// sets a capability property of a node, the empty capability being the node itself
+func (n *BeamNode) setCapability(capability string, name string, value string) {
+    for _, c := range n.Capabilities {
+        if c.Name == capability {
+            c.Properties = setProperty(c.Properties, name, value)
+            return
+        }
+    }
+    n.Capabilities = append(n.Capabilities, &BeamCapability{Name: capability, Properties: []BeamProperty{{Name: name, Value: value}}})
+}
+
+// returns the named probe of the document
+func (d *BeamDocument) probe(name string) *BeamProbe {
+    for _, p := range d.Probes {
+        if p.Name == name {
+            return p
+        }
+    }
+    return nil
+}
+
+// returns true when some node uses the node as its base
+func (d *BeamDocument) layered(n *BeamNode) bool {
+    for _, item := range d.Nodes {
+        if item.base == n {
+            return true
+        }
+    }
+    return false
+}
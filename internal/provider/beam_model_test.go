@@ -0,0 +1,209 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testBeamData returns the data stream of the path and value pairs.
+func testBeamData(pairs ...string) []BeamChange {
+	var data []BeamChange
+	for i := 0; i+1 < len(pairs); i += 2 {
+		data = append(data, BeamChange{Path: pairs[i], Value: pairs[i+1]})
+	}
+	return data
+}
+
+// testBeamIssue is the expected summary, severity and list index of an issue.
+type testBeamIssue struct {
+	Index   int
+	Warning bool
+	Summary string
+}
+
+func testBeamIssues(issues []BeamIssue) []testBeamIssue {
+	var result []testBeamIssue
+	for _, issue := range issues {
+		result = append(result, testBeamIssue{Index: issue.Index, Warning: issue.Warning, Summary: issue.Summary})
+	}
+	return result
+}
+
+func TestCompileBeamData(t *testing.T) {
+	tests := map[string]struct {
+		data      []BeamChange
+		issues    []testBeamIssue
+		nodes     int
+		probes    int
+		types     int
+		probeRefs []string
+	}{
+		"empty": {},
+		"layered nodes": {
+			data: testBeamData(
+				"node.1.name", "hwn1",
+				"node.1.type", "Compute",
+				"node.2.name", "swn2",
+				"node.2.type", "Database",
+				"node.2.base", "hwn1",
+				"relation.node.2.hostname", "node.1",
+			),
+			nodes: 2,
+		},
+		"white space in paths": {
+			data: testBeamData(
+				"node . 1 . name", "hwn1",
+				"node.2.base", "1",
+				"relation . node . 2 . hostname", "hwn1",
+			),
+			nodes: 2,
+		},
+		"compute without software": {
+			data:   testBeamData("node.1.name", "hwn1", "node.1.type", "Compute"),
+			issues: []testBeamIssue{{Index: 1, Warning: true, Summary: "BEAM Compute Node Without Software"}},
+			nodes:  1,
+		},
+		"undefined base name": {
+			data:   testBeamData("node.1.base", "missing"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Undefined BEAM Node"}},
+			nodes:  1,
+		},
+		"template base number": {
+			data:   testBeamData("node.2.base", "1"),
+			issues: []testBeamIssue{{Index: 0, Warning: true, Summary: "Undefined BEAM Node"}},
+			nodes:  2,
+		},
+		"undefined relation target": {
+			data: testBeamData(
+				"node.1.name", "swn1",
+				"relation.node.1.contract", "node.dbn",
+			),
+			issues: []testBeamIssue{{Index: 1, Summary: "Undefined BEAM Node"}},
+			nodes:  1,
+		},
+		"unexpected relation subject": {
+			data: testBeamData(
+				"node.1.name", "a",
+				"node.2.name", "b",
+				"relation.node.1.owner", "b",
+			),
+			issues: []testBeamIssue{{Index: 2, Warning: true, Summary: "Unexpected BEAM Relation Subject"}},
+			nodes:  2,
+		},
+		"copy of the last node": {
+			data:  testBeamData("node.1.name", "a", "copy.node", "last"),
+			nodes: 2,
+		},
+		"copy without nodes": {
+			data:   testBeamData("copy.node", "last"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Undefined BEAM Node"}},
+			nodes:  1,
+		},
+		"copy of an undefined probe": {
+			data:   testBeamData("copy.probe", "memory"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Undefined BEAM Probe"}},
+			probes: 1,
+		},
+		"probe tags": {
+			data: testBeamData(
+				"tag.Probe", "disk",
+				"tag.Probe", "memory",
+				"probe.1.name", "disk",
+			),
+			probes:    1,
+			probeRefs: []string{"memory"},
+		},
+		"node types": {
+			data:  testBeamData("type.1.name", "CompanyMySql", "type.CompanyMySql.derived_from", "Database", "type.Other.version", "1"),
+			types: 2,
+		},
+		"unrecognised path": {
+			data:   testBeamData("nodes.1.name", "a"),
+			issues: []testBeamIssue{{Index: 0, Warning: true, Summary: "Unrecognised BEAM Data Path"}},
+		},
+		"unknown values": {
+			data:  []BeamChange{{Path: "node.1.base", Unknown: true}, {Path: "node.1.name", Value: "a"}},
+			nodes: 1,
+		},
+		"template node beyond the data": {
+			data:  testBeamData("node.20.name", "a"),
+			nodes: 20,
+		},
+		"absurd node index": {
+			data:   testBeamData("node.100000000.name", "a"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Invalid BEAM Index"}},
+		},
+		"absurd capability node index": {
+			data:   testBeamData("node.1", "", "node.99999999.host.num_cpus", "2"),
+			issues: []testBeamIssue{{Index: 0, Warning: true, Summary: "Unrecognised BEAM Data Path"}, {Index: 1, Summary: "Invalid BEAM Index"}},
+		},
+		"absurd probe index": {
+			data:   testBeamData("probe.100000000.name", "a"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Invalid BEAM Index"}},
+		},
+		"absurd type index": {
+			data:   testBeamData("type.100000000.name", "a"),
+			issues: []testBeamIssue{{Index: 0, Summary: "Invalid BEAM Index"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, issues := CompileBeamData(test.data)
+			if got := testBeamIssues(issues); !reflect.DeepEqual(got, test.issues) {
+				t.Errorf("expected the issues %v, got %v", test.issues, got)
+			}
+			if len(doc.Nodes) != test.nodes || len(doc.Probes) != test.probes || len(doc.Types) != test.types {
+				t.Errorf("expected %d nodes, %d probes and %d types, got %d, %d and %d",
+					test.nodes, test.probes, test.types, len(doc.Nodes), len(doc.Probes), len(doc.Types))
+			}
+			if !reflect.DeepEqual(doc.ProbeRefs, test.probeRefs) {
+				t.Errorf("expected the probe references %v, got %v", test.probeRefs, doc.ProbeRefs)
+			}
+		})
+	}
+}
+
+func TestCompileBeamDataCopy(t *testing.T) {
+	doc, issues := CompileBeamData(testBeamData(
+		"node.1.name", "web",
+		"node.1.host.num_cpus", "2",
+		"copy.node", "web",
+		"node.2.name", "web2",
+		"node.2.host.num_cpus", "4",
+	))
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues %v", issues)
+	}
+	if doc.Nodes[0].Property("host", "num_cpus") != "2" || doc.Nodes[1].Property("host", "num_cpus") != "4" {
+		t.Errorf("expected the copied node to have its own capabilities")
+	}
+}
+
+func TestBeamPositionalPath(t *testing.T) {
+	tests := map[string]bool{
+		"node.1.name":              false,
+		"node.1.host.num_cpus":     false,
+		"tag.Title":                false,
+		"type.1.derived_from":      false,
+		"probe.disk.name":          false,
+		"tag.Probe":                true,
+		"tag . Probe":              true,
+		"import":                   true,
+		"copy.node":                true,
+		"copy.probe":               true,
+		"relation.node.2.hostname": true,
+		"node.1.endpoint.tcp_port": true,
+		"node.1.udp_range":         true,
+		"node.last.name":           true,
+		"probe.last.interval":      true,
+	}
+	for p, expected := range tests {
+		if got := BeamPositionalPath(p); got != expected {
+			t.Errorf("BeamPositionalPath(%q): expected %v, got %v", p, expected, got)
+		}
+	}
+}
@@ -2,10 +2,20 @@ package provider
 
 import (
     "context"
+    "encoding/json"
+    "errors"
     "fmt"
+    "os"
+    "strings"
     "time"
+    "github.com/hashicorp/terraform-plugin-framework/diag"
+    "github.com/hashicorp/terraform-plugin-framework/path"
     "github.com/hashicorp/terraform-plugin-framework/resource"
     "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/tfsdk"
     "github.com/hashicorp/terraform-plugin-framework/types"
     "github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -14,6 +24,9 @@ import (
 var (
     _ resource.Resource              = &beamResource{}
     _ resource.ResourceWithConfigure = &beamResource{}
+    _ resource.ResourceWithValidateConfig = &beamResource{}
+    _ resource.ResourceWithModifyPlan = &beamResource{}
+    _ resource.ResourceWithImportState = &beamResource{}
 )
 
 // NewBeamResource is a helper function to simplify the provider implementation.
@@ -37,7 +50,15 @@ type beamResourceModel struct {
     Param	types.String     `tfsdk:"param"`
     State       types.String     `tfsdk:"state"`
     LastUpdated types.String     `tfsdk:"last_updated"`
+    SourceFile    types.String   `tfsdk:"source_file"`
+    SourceContent types.String   `tfsdk:"source_content"`
     Data        []beamChangeModel   `tfsdk:"data"`
+    SecretData  []beamChangeModel   `tfsdk:"secret_data"`
+    SecretDataVersion types.Int64   `tfsdk:"secret_data_version"`
+    ModelName   types.String     `tfsdk:"model_name"`
+    ToscaYaml   types.String     `tfsdk:"tosca_yaml"`
+    TopologyDot types.String     `tfsdk:"topology_dot"`
+    TopologyMermaid types.String `tfsdk:"topology_mermaid"`
 }
 
 // the beam change request model
@@ -56,6 +77,9 @@ func (r *beamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
         Attributes: map[string]schema.Attribute{
             "id": schema.StringAttribute{
                 Computed: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
             },
             "state": schema.StringAttribute{
                 Computed: true,
@@ -63,32 +87,74 @@ func (r *beamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
             "last_updated": schema.StringAttribute{
                 Computed: true,
             },
+            "model_name": schema.StringAttribute{
+                Computed: true,
+                Description: "The name of the BEAM model cloned from the template, for use as the template of apps and other beams.",
+            },
+            "tosca_yaml": schema.StringAttribute{
+                Computed: true,
+                Description: "The TOSCA service template YAML document compiled locally from the data list.",
+            },
+            "topology_dot": schema.StringAttribute{
+                Computed: true,
+                Description: "The Graphviz DOT diagram of the nodes, base layering and relations of the data list.",
+            },
+            "topology_mermaid": schema.StringAttribute{
+                Computed: true,
+                Description: "The Mermaid flowchart of the nodes, base layering and relations of the data list.",
+            },
             "template": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "program": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
             },
             "domain": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    requiresReplaceUnlessImported(),
+                },
             },
             "region": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    requiresReplaceUnlessImported(),
+                },
             },
             "category": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    requiresReplaceUnlessImported(),
+                },
             },
             "param": &schema.StringAttribute{
                 Computed: false,
 		Required: true,
+                PlanModifiers: []planmodifier.String{
+                    requiresReplaceUnlessImported(),
+                },
+            },
+            "source_file": schema.StringAttribute{
+                Optional: true,
+                Description: "The path of a TOSCA or BEAM service template YAML document whose content is applied before the data list.",
+            },
+            "source_content": schema.StringAttribute{
+                Optional: true,
+                Description: "The content of a TOSCA or BEAM service template YAML document applied before the data list.",
             },
             "data": &schema.ListNestedAttribute{
-		Required: true,
+		Optional: true,
 		NestedObject: schema.NestedAttributeObject{
 		    Attributes: map[string]schema.Attribute{
 		        "path": schema.StringAttribute{
@@ -102,10 +168,520 @@ func (r *beamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
                     },
 		},
             },
+            "secret_data": schema.ListNestedAttribute{
+                Optional: true,
+                WriteOnly: true,
+                Description: "Changes applied after the data list whose values, such as passwords, are never stored in the plan or state.",
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "path": schema.StringAttribute{
+                            Required: true,
+                            WriteOnly: true,
+                        },
+                        "value": schema.StringAttribute{
+                            Required: true,
+                            WriteOnly: true,
+                        },
+                    },
+                },
+            },
+            "secret_data_version": schema.Int64Attribute{
+                Optional: true,
+                Description: "Changing this value replaces the BEAM so that modified secret_data values are applied. Setting a value where none was set, such as for an imported BEAM, applies the secret_data values in place.",
+                PlanModifiers: []planmodifier.Int64{
+                    int64planmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+                        resp.RequiresReplace = !req.StateValue.IsNull()
+                    }, secretDataVersionDescription, secretDataVersionDescription),
+                },
+            },
         },
     }
 }
 
+const importedDescription = "If the value of this attribute changes, Terraform will destroy and recreate the resource, unless it was unknown since the import of the resource."
+
+const secretDataVersionDescription = "If the value of this attribute changes, Terraform will destroy and recreate the resource so that the modified secret_data values are applied, unless it was unset, when the secret_data values are applied by an update."
+
+// requires the replacement of the resource when the value changes, except
+// for the values that the import of the resource could not recover
+func requiresReplaceUnlessImported() planmodifier.String {
+    return stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+        resp.RequiresReplace = !req.StateValue.IsNull()
+    }, importedDescription, importedDescription)
+}
+
+// converts the data list of the resource to the BEAM data stream
+func beamChanges(items []beamChangeModel) []BeamChange {
+    var data []BeamChange
+    for _, item := range items {
+        data = append(data, BeamChange{
+            Path:    item.Path.ValueString(),
+            Value:   item.Value.ValueString(),
+            Unknown: item.Path.IsUnknown() || item.Value.IsUnknown(),
+        })
+    }
+    return data
+}
+
+// returns the BEAM data stream derived from the source file or content, if any
+func beamSource(file types.String, content types.String) ([]BeamChange, error) {
+    if !file.IsNull() {
+        b, err := os.ReadFile(file.ValueString())
+        if err != nil {
+            return nil, err
+        }
+        return BeamSourceChanges(string(b))
+    }
+    if !content.IsNull() {
+        return BeamSourceChanges(content.ValueString())
+    }
+    return nil, nil
+}
+
+// returns the write-only secret changes of the configuration
+func beamSecrets(ctx context.Context, config tfsdk.Config) ([]BeamChange, diag.Diagnostics) {
+    var items []beamChangeModel
+    diags := config.GetAttribute(ctx, path.Root("secret_data"), &items)
+    return beamChanges(items), diags
+}
+
+// returns the context masking the secret values in all log output
+func maskBeamSecrets(ctx context.Context, secrets []BeamChange) context.Context {
+    var values []string
+    for _, item := range secrets {
+        values = append(values, item.Value)
+    }
+    return withLogSecretData(ctx, values...)
+}
+
+// returns the attribute providing the source document of the BEAM stream
+func beamSourceAttribute(file types.String) path.Path {
+    if !file.IsNull() {
+        return path.Root("source_file")
+    }
+    return path.Root("source_content")
+}
+
+// returns the attribute of the change of the BEAM data stream at the
+// index, being the source document, the data list or the secret data
+func beamStreamAttribute(file types.String, derived int, listed int, index int) path.Path {
+    switch {
+    case index >= listed:
+        return path.Root("secret_data").AtListIndex(index - listed)
+    case index < derived:
+        return beamSourceAttribute(file)
+    }
+    return path.Root("data").AtListIndex(index - derived)
+}
+
+// the private state key of the BEAM data stream applied to the model
+const beamStreamKey = "stream"
+
+// ---------------------------------------------------------------
+// DIFF BEAM STREAM
+// ---------------------------------------------------------------
+// Returns the changes of the planned BEAM data stream that must be
+// applied to a BEAM model to which the prior stream was applied,
+// being the last change of each path whose value differs from the
+// last value of the path in the prior stream, in planned order.
+// As ACE cannot remove a path from a model, nor undo a positional
+// change, the reason for the replacement of the model is returned
+// instead when a path is removed, when the positional changes are
+// not exactly those of the prior stream, or when a path changes
+// before a copy, which could have copied its value.
+// ---------------------------------------------------------------
+func diffBeamStream(prior []BeamChange, planned []BeamChange) ([]BeamChange, string) {
+    key := func(p string) string {
+        return strings.Join(BeamPath(p), ".")
+    }
+    var priorPositional, plannedPositional []BeamChange
+    priorValues := map[string]string{}
+    var priorPaths []string
+    for _, item := range prior {
+        k := key(item.Path)
+        if BeamPositionalPath(item.Path) {
+            priorPositional = append(priorPositional, BeamChange{Path: k, Value: item.Value})
+            continue
+        }
+        if _, ok := priorValues[k]; !ok {
+            priorPaths = append(priorPaths, k)
+        }
+        priorValues[k] = item.Value
+    }
+    first, last := map[string]int{}, map[string]int{}
+    lastCopy := -1
+    for i, item := range planned {
+        k := key(item.Path)
+        if BeamPositionalPath(item.Path) {
+            plannedPositional = append(plannedPositional, BeamChange{Path: k, Value: item.Value})
+            if BeamPath(item.Path)[0] == "copy" {
+                lastCopy = i
+            }
+            continue
+        }
+        if _, ok := first[k]; !ok {
+            first[k] = i
+        }
+        last[k] = i
+    }
+
+    for i := 0; i < len(priorPositional) || i < len(plannedPositional); i++ {
+        if i >= len(priorPositional) || i >= len(plannedPositional) || priorPositional[i] != plannedPositional[i] {
+            p := ""
+            if i < len(plannedPositional) {
+                p = plannedPositional[i].Path
+            } else {
+                p = priorPositional[i].Path
+            }
+            return nil, "The positional change of the path "+p+", which adds to the BEAM model or depends upon its position in the data stream, was added, removed or changed."
+        }
+    }
+    for _, k := range priorPaths {
+        if _, ok := last[k]; !ok {
+            return nil, "The path "+k+" was removed from the data stream and cannot be removed from the BEAM model."
+        }
+    }
+
+    var changes []BeamChange
+    for i, item := range planned {
+        k := key(item.Path)
+        if BeamPositionalPath(item.Path) || last[k] != i {
+            continue
+        }
+        if value, ok := priorValues[k]; ok && value == item.Value {
+            continue
+        }
+        if first[k] < lastCopy {
+            return nil, "The path "+k+" was changed before a later copy change of the data stream, to which the change cannot be applied."
+        }
+        changes = append(changes, item)
+    }
+    return changes, ""
+}
+
+// ---------------------------------------------------------------
+// REFRESH BEAM STREAM
+// ---------------------------------------------------------------
+// Returns the BEAM data stream applied to a model refreshed with
+// the values of the stream exported from the model, and whether
+// any value was changed outside of Terraform. Only the paths of
+// the exported stream are refreshed, positional paths and paths
+// addressing nodes by name being left unchanged.
+// ---------------------------------------------------------------
+func refreshBeamStream(stream []BeamChange, exported []BeamChange) ([]BeamChange, bool) {
+    key := func(p string) string {
+        return strings.Join(BeamPath(p), ".")
+    }
+    values := map[string]string{}
+    for _, item := range exported {
+        if !BeamPositionalPath(item.Path) {
+            values[key(item.Path)] = item.Value
+        }
+    }
+    refreshed := append([]BeamChange{}, stream...)
+    drifted := false
+    for i, item := range refreshed {
+        if BeamPositionalPath(item.Path) {
+            continue
+        }
+        if value, ok := values[key(item.Path)]; ok && value != item.Value {
+            refreshed[i].Value = value
+            drifted = true
+        }
+    }
+    return refreshed, drifted
+}
+
+// ---------------------------------------------------------------
+// PRIOR BEAM STREAM
+// ---------------------------------------------------------------
+// Returns the BEAM data stream applied to the model of the state,
+// as recorded in the private state by create and update, or else
+// as derived from the source and data list of the state. As that
+// applied to an imported BEAM is not known, false is returned for
+// an imported BEAM until its first update.
+// ---------------------------------------------------------------
+func priorBeamStream(ctx context.Context, getKey func(context.Context, string) ([]byte, diag.Diagnostics), state beamResourceModel) ([]BeamChange, bool, diag.Diagnostics) {
+    value, diags := getKey(ctx, beamStreamKey)
+    if diags.HasError() {
+        return nil, false, diags
+    }
+    if value != nil {
+        var prior []BeamChange
+        if err := json.Unmarshal(value, &prior); err != nil {
+            diags.AddError("Invalid BEAM Private State", "The applied BEAM data stream could not be read: "+err.Error())
+            return nil, false, diags
+        }
+        return prior, true, diags
+    }
+    if state.Domain.IsNull() {
+        return nil, false, diags
+    }
+    source, err := beamSource(state.SourceFile, state.SourceContent)
+    if err != nil {
+        return nil, false, diags
+    }
+    return append(source, beamChanges(state.Data)...), true, diags
+}
+
+// records the BEAM data stream applied to the model in the private state
+func setBeamStream(ctx context.Context, setKey func(context.Context, string, []byte) diag.Diagnostics, stream []BeamChange) diag.Diagnostics {
+    value, _ := json.Marshal(append([]BeamChange{}, stream...))
+    return setKey(ctx, beamStreamKey, value)
+}
+
+// ---------------------------------------------------------------
+// GET BEAM STREAM
+// ---------------------------------------------------------------
+// Retrieves the BEAM data stream from configuration or plan data,
+// being the changes derived from the source document followed by
+// those of the data list, and the number of derived changes. The
+// stream is nil when the source or data list are not yet known.
+// ---------------------------------------------------------------
+func getBeamStream(ctx context.Context, get func(context.Context, path.Path, interface{}) diag.Diagnostics) ([]BeamChange, int, diag.Diagnostics) {
+    var file, content types.String
+    var list types.List
+    var diags diag.Diagnostics
+    diags.Append(get(ctx, path.Root("source_file"), &file)...)
+    diags.Append(get(ctx, path.Root("source_content"), &content)...)
+    diags.Append(get(ctx, path.Root("data"), &list)...)
+    if diags.HasError() || file.IsUnknown() || content.IsUnknown() || list.IsUnknown() {
+        return nil, 0, diags
+    }
+    if !file.IsNull() && !content.IsNull() {
+        diags.AddAttributeError(path.Root("source_content"), "Conflicting BEAM Source",
+            "Only one of source_file and source_content may be specified.")
+        return nil, 0, diags
+    }
+    source, err := beamSource(file, content)
+    if err != nil {
+        diags.AddAttributeError(beamSourceAttribute(file), "Invalid BEAM Source Document",
+            "The TOSCA service template could not be translated to BEAM data: "+err.Error())
+        return nil, 0, diags
+    }
+    var items []beamChangeModel
+    diags.Append(list.ElementsAs(ctx, &items, false)...)
+    data := append(source, beamChanges(items)...)
+    for _, item := range data {
+        if item.Unknown {
+            return nil, 0, diags
+        }
+    }
+    return data, len(source), diags
+}
+
+// -------------------------------------------------
+// VALIDATE BEAM RESOURCE
+// -------------------------------------------------
+// Compiles the data list of the BEAM resource to
+// ensure that every base, relation source, target,
+// copy source and probe tag refers to an element
+// defined in the data stream. Compute nodes that
+// have no software layer give rise to a warning.
+// -------------------------------------------------
+func (r *beamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+    data, derived, diags := getBeamStream(ctx, req.Config.GetAttribute)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || data == nil {
+        return
+    }
+
+    var file types.String
+    var secretList types.List
+    var secrets []beamChangeModel
+    req.Config.GetAttribute(ctx, path.Root("source_file"), &file)
+    resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_data"), &secretList)...)
+    if resp.Diagnostics.HasError() || secretList.IsUnknown() {
+        return
+    }
+    resp.Diagnostics.Append(secretList.ElementsAs(ctx, &secrets, false)...)
+    listed := len(data)
+    data = append(data, beamChanges(secrets)...)
+    _, issues := CompileBeamData(data)
+    for _, issue := range issues {
+        at := beamStreamAttribute(file, derived, listed, issue.Index)
+        detail := issue.Detail
+        if issue.Index < derived {
+            detail += " The path " + data[issue.Index].Path + " is derived from the source document."
+        }
+        if issue.Warning {
+            resp.Diagnostics.AddAttributeWarning(at, issue.Summary, detail)
+        } else {
+            resp.Diagnostics.AddAttributeError(at, issue.Summary, detail)
+        }
+    }
+}
+
+// sets the values of the data list from those of the BEAM data stream ending with it
+func (m *beamResourceModel) refreshData(stream []BeamChange) {
+    offset := len(stream) - len(m.Data)
+    if offset < 0 {
+        return
+    }
+    for i, item := range m.Data {
+        if change := stream[offset+i]; change.Path == item.Path.ValueString() {
+            m.Data[i].Value = types.StringValue(change.Value)
+        }
+    }
+}
+
+// sets the TOSCA document and topology diagrams compiled from the BEAM data stream
+func (m *beamResourceModel) setDocument(data []BeamChange) {
+    doc, _ := CompileBeamData(data)
+    m.ToscaYaml = types.StringValue(RenderBeamTosca(doc))
+    m.TopologyDot = types.StringValue(RenderBeamDot(doc))
+    m.TopologyMermaid = types.StringValue(RenderBeamMermaid(doc))
+}
+
+// -------------------------------------------------
+// MODIFY BEAM PLAN
+// -------------------------------------------------
+// Derives the name of the cloned BEAM model and
+// compiles the data stream of the planned BEAM so
+// that the resulting TOSCA document and topology
+// diagrams may be read in the plan output, whenever
+// the stream is known, and refuses the probe tags
+// of the stream that name no probe of the account.
+// The BEAM is replaced when its planned stream
+// cannot be applied as changes of the prior stream
+// to the existing model.
+// -------------------------------------------------
+func (r *beamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+    if req.Plan.Raw.IsNull() {
+        return
+    }
+    var template, program types.String
+    resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("template"), &template)...)
+    resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("program"), &program)...)
+    if !template.IsUnknown() && !program.IsUnknown() {
+        if name, ok := BeamModelName(template.ValueString(), program.ValueString()); ok {
+            resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("model_name"), types.StringValue(name))...)
+        } else if !req.State.Raw.IsNull() {
+            // the name assigned by ACE is kept unless the beam is replaced
+            var prior beamResourceModel
+            resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+            if prior.Template.Equal(template) && prior.Program.Equal(program) {
+                resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("model_name"), prior.ModelName)...)
+            }
+        }
+    }
+    data, derived, diags := getBeamStream(ctx, req.Plan.GetAttribute)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || data == nil {
+        return
+    }
+    var m beamResourceModel
+    m.setDocument(data)
+    resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tosca_yaml"), m.ToscaYaml)...)
+    resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("topology_dot"), m.TopologyDot)...)
+    resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("topology_mermaid"), m.TopologyMermaid)...)
+    var file types.String
+    resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("source_file"), &file)...)
+    resp.Diagnostics.Append(r.checkProbeTags(ctx, file, data, derived)...)
+    if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+        return
+    }
+
+    var state beamResourceModel
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    prior, known, diags := priorBeamStream(ctx, req.Private.GetKey, state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || !known {
+        return
+    }
+    if _, reason := diffBeamStream(prior, data); reason != "" {
+        // Terraform replaces the BEAM for those of the attributes that change
+        tflog.Info(ctx,"AMENESIK:BEAM PLAN: REPLACE: "+reason)
+        resp.RequiresReplace = append(resp.RequiresReplace,
+            path.Root("source_file"), path.Root("source_content"), path.Root("data"))
+    }
+}
+
+// ---------------------------------------------------------------
+// CHECK PROBE TAGS
+// ---------------------------------------------------------------
+// Refuses probe tags naming neither a probe of the document nor
+// an account level probe definition, at the change of the tag.
+// The check is performed by the plan of a known data stream and
+// again by create and update, for the streams only known during
+// apply, such as those naming the id of an amenesik_probe of the
+// same configuration. A failure to list the probes of the account
+// is only logged.
+// ---------------------------------------------------------------
+func (r *beamResource) checkProbeTags(ctx context.Context, file types.String, data []BeamChange, derived int) diag.Diagnostics {
+    var diags diag.Diagnostics
+    doc, _ := CompileBeamData(data)
+    if r.client == nil || len(doc.ProbeRefs) == 0 {
+        return diags
+    }
+    probes, err := r.client.ListBeamProbes(ctx)
+    if err != nil {
+        tflog.Warn(ctx,"AMENESIK:BEAM CHECK: PROBES: "+err.Error())
+        return diags
+    }
+    known := map[string]bool{}
+    for _, p := range probes {
+        known[p.Name] = true
+    }
+    for n, name := range doc.ProbeRefs {
+        if !known[name] {
+            diags.AddAttributeError(
+                beamStreamAttribute(file, derived, len(data), doc.probeRefIndex[n]),
+                "Undefined BEAM Probe",
+                fmt.Sprintf("The tag.Probe value %q names neither a probe defined in the data list nor a probe of the account.", name),
+            )
+        }
+    }
+    return diags
+}
+
+// ---------------------------------------------------------------
+// CHECK NODE TYPES
+// ---------------------------------------------------------------
+// Warns of software nodes whose type is neither a local type of
+// the document nor one of the node types known to the account, at
+// the change defining the type. The check is performed by create
+// and update only, so that plans never require the account to be
+// reached. A failure to list the node types is only logged.
+// ---------------------------------------------------------------
+func (r *beamResource) checkNodeTypes(ctx context.Context, file types.String, data []BeamChange, derived int) diag.Diagnostics {
+    var diags diag.Diagnostics
+    doc, _ := CompileBeamData(data)
+    local := map[string]bool{}
+    for _, t := range doc.Types {
+        local[strings.ToLower(beamType(t.Name))] = true
+    }
+    var nodes []*BeamNode
+    for _, n := range doc.Nodes {
+        t := strings.ToLower(beamType(n.Type))
+        if t != "" && t != "compute" && !local[t] {
+            nodes = append(nodes, n)
+        }
+    }
+    if r.client == nil || len(nodes) == 0 {
+        return diags
+    }
+    nodeTypes, err := r.client.ListBeamNodeTypes(ctx)
+    if err != nil {
+        tflog.Warn(ctx,"AMENESIK:BEAM CHECK: NODE TYPES: "+err.Error())
+        return diags
+    }
+    known := map[string]bool{}
+    for _, t := range nodeTypes {
+        known[strings.ToLower(beamType(t.Name))] = true
+    }
+    for _, n := range nodes {
+        if !known[strings.ToLower(beamType(n.Type))] {
+            diags.AddAttributeWarning(
+                beamStreamAttribute(file, derived, len(data), n.typeIndex),
+                "BEAM Node Type Not Found",
+                "The type "+n.Type+" of the node "+doc.NodeName(n)+" is neither a local type of the document nor a node type known to the account.",
+            )
+        }
+    }
+    return diags
+}
+
 // -------------------------------------------------
 // CREATE BEAM RESOURCE
 // -------------------------------------------------
@@ -137,36 +713,64 @@ func (r *beamResource) Create(ctx context.Context, req resource.CreateRequest, r
     region   := plan.Region.String()
     category := plan.Category.String()
 
+    // derive the change stream from the source document and data list
+    source, err := beamSource(plan.SourceFile, plan.SourceContent)
+    if err != nil {
+        resp.Diagnostics.AddAttributeError(beamSourceAttribute(plan.SourceFile), "Invalid BEAM Source Document", err.Error())
+        return
+    }
+    stream := append(source, beamChanges(plan.Data)...)
+    resp.Diagnostics.Append(r.checkNodeTypes(ctx, plan.SourceFile, stream, len(source))...)
+    resp.Diagnostics.Append(r.checkProbeTags(ctx, plan.SourceFile, stream, len(source))...)
+
+    // the write-only secret changes are only available from the configuration
+    secrets, diags := beamSecrets(ctx, req.Config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+    ctx = maskBeamSecrets(ctx, secrets)
+
     var br *BeamResponse
 
     // CLONE a BEAM model with specific provisioning characteristics and action data
     br, err = r.client.CloneBeamModel(ctx,template, program, domain, region, category )
     if err != nil {
 	tflog.Info(ctx,"AMENESIK:BEAM ERROR: CLONE BEAM MODEL: "+err.Error());
+        resp.Diagnostics.AddError(
+            "Unable to Create Amenesik Beam",
+            "Amenesik Client Error: "+err.Error(),
+        )
         return
     }
+    plan.ID = types.StringValue(plan.Template.ValueString()+"/"+plan.Program.ValueString())
+    plan.ModelName = types.StringValue(br.result.name)
+    plan.setDocument(stream)
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
-    for _, item := range plan.Data {
-    	// prepare the Change Request
-    	data := UnQuote(item.Path.String())+":"+UnQuote(item.Value.String())
-
-    	// CHANGE a BEAM model with specific provisioning characteristics and action data
-    	br, err = r.client.ChangeBeamModel(ctx,template, program, domain, region, category, data )
-    	if err != nil {
-		tflog.Info(ctx,"AMENESIK:BEAM ERROR: CHANGE BEAM MODEL: "+err.Error());
-	        return
-	}
+    // CHANGE the BEAM model, recording a partially changed model in the
+    // state, where Terraform marks it as tainted, so that it is replaced
+    // by the next apply
+    br, err = r.changeBeamModel(ctx, &plan, append(stream, secrets...))
+    if err != nil {
+        plan.State = types.StringValue("failed")
+        resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+        resp.Diagnostics.AddError(
+            "Unable to Create Amenesik Beam",
+            "The change of the cloned BEAM model failed. The partially created beam will be replaced by the next apply.\n\n"+
+                "Amenesik Client Error: "+err.Error(),
+        )
+        return
     }
 
     // prepare the final state description
     if br.status == "200" {
 	    br.status = "created"
     }
-    plan.ID = types.StringValue(time.Now().Format(time.RFC3339))
     plan.State = types.StringValue(br.status)
-    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
     diags = resp.State.Set(ctx, plan)
     resp.Diagnostics.Append(diags...)
+    resp.Diagnostics.Append(setBeamStream(ctx, resp.Private.SetKey, stream)...)
     if resp.Diagnostics.HasError() {
         return
     }
@@ -174,12 +778,173 @@ func (r *beamResource) Create(ctx context.Context, req resource.CreateRequest, r
     return
 }
 
-// Read refreshes the Terraform state with the latest data.
+// -------------------------------------------------
+// Applies the changes of the BEAM data stream to the
+// BEAM model of the resource, in order, returning
+// the response of the last change.
+// -------------------------------------------------
+func (r *beamResource) changeBeamModel(ctx context.Context, m *beamResourceModel, stream []BeamChange) (*BeamResponse, error) {
+    br := &BeamResponse{status: "200"}
+    for _, item := range stream {
+    	// prepare the Change Request
+    	data := item.Path+":"+item.Value
+
+    	// CHANGE a BEAM model with specific provisioning characteristics and action data
+    	rr, err := r.client.ChangeBeamModel(ctx, m.Template.String(), m.Program.String(), m.Domain.String(), m.Region.String(), m.Category.String(), data )
+    	if err != nil {
+		tflog.Info(ctx,"AMENESIK:BEAM ERROR: CHANGE BEAM MODEL: "+err.Error());
+	        return nil, err
+	}
+	br = rr
+    }
+    return br, nil
+}
+
+// -------------------------------------------------
+// READ BEAM RESOURCE
+// -------------------------------------------------
+// Verifies that the BEAM model of the resource still
+// exists, the resource being removed from the state
+// when the model has been deleted outside of
+// Terraform so that it is created again. The values
+// of the exported model that were changed outside
+// of Terraform are refreshed in the data list and
+// in the derived document and diagrams.
+// -------------------------------------------------
 func (r *beamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state beamResourceModel
+    tflog.Info(ctx,"AMENESIK:BEAM ENTER:READ: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    content, err := r.client.ExportBeamModel(ctx, state.Template.String(), state.Program.String())
+    if errors.Is(err, ErrBeamNotFound) {
+        tflog.Info(ctx,"AMENESIK:BEAM LEAVE:READ: NOT FOUND");
+        resp.State.RemoveResource(ctx)
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik Beam",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    // the values changed outside of Terraform are refreshed in the data
+    // list and in the applied stream, so that the next plan restores them
+    prior, known, diags := priorBeamStream(ctx, req.Private.GetKey, state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+    if exported, err := BeamSourceChanges(content); err != nil {
+        tflog.Warn(ctx,"AMENESIK:BEAM READ: EXPORT: "+err.Error())
+    } else if known {
+        if stream, drifted := refreshBeamStream(prior, exported); drifted {
+            tflog.Info(ctx,"AMENESIK:BEAM READ: DRIFT")
+            state.refreshData(stream)
+            state.setDocument(stream)
+            resp.Diagnostics.Append(setBeamStream(ctx, resp.Private.SetKey, stream)...)
+        }
+    }
+
+    // the values of an imported beam are completed
+    if name, ok := BeamModelName(state.Template.ValueString(), state.Program.ValueString()); ok {
+        state.ModelName = types.StringValue(name)
+    }
+    if state.State.IsNull() {
+        state.State = types.StringValue("created")
+    }
+    diags = resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:BEAM LEAVE:READ: SUCCESS");
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
+// -------------------------------------------------
+// UPDATE BEAM RESOURCE
+// -------------------------------------------------
+// Applies the changes of the planned data stream,
+// being those of the source document and of the
+// data list, that differ from the stream applied to
+// the BEAM model, whose removed or positional paths
+// require its replacement instead. The whole stream
+// is applied to an imported BEAM, together with the
+// write-only secret changes of the configuration,
+// which are otherwise only applied by create.
+// -------------------------------------------------
 func (r *beamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan, state beamResourceModel
+    tflog.Info(ctx,"AMENESIK:BEAM ENTER:UPDATE: Get Plan");
+    resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+    resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    // derive the change stream from the source document and data list
+    source, err := beamSource(plan.SourceFile, plan.SourceContent)
+    if err != nil {
+        resp.Diagnostics.AddAttributeError(beamSourceAttribute(plan.SourceFile), "Invalid BEAM Source Document", err.Error())
+        return
+    }
+    stream := append(source, beamChanges(plan.Data)...)
+    resp.Diagnostics.Append(r.checkNodeTypes(ctx, plan.SourceFile, stream, len(source))...)
+    resp.Diagnostics.Append(r.checkProbeTags(ctx, plan.SourceFile, stream, len(source))...)
+
+    // only the changes of the stream applied to the model are applied again
+    prior, known, diags := priorBeamStream(ctx, req.Private.GetKey, state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+    changes := stream
+    if known {
+        var reason string
+        if changes, reason = diffBeamStream(prior, stream); reason != "" {
+            // the plan could not tell as the stream was not yet known
+            resp.Diagnostics.AddError(
+                "Unable to Update Amenesik Beam",
+                "The BEAM must be replaced, as the data stream known only during apply cannot be applied to the existing BEAM model. "+
+                    "Run terraform apply -replace to replace it.\n\n"+reason,
+            )
+            return
+        }
+    }
+
+    // the write-only secret changes are applied again with a new secret
+    // data version, which replaces the BEAM unless it was imported
+    var secrets []BeamChange
+    if !plan.SecretDataVersion.Equal(state.SecretDataVersion) {
+        secrets, diags = beamSecrets(ctx, req.Config)
+        resp.Diagnostics.Append(diags...)
+        if resp.Diagnostics.HasError() {
+            return
+        }
+    }
+    ctx = maskBeamSecrets(ctx, secrets)
+
+    tflog.Info(ctx,fmt.Sprintf("AMENESIK:BEAM UPDATE: %d OF %d CHANGES", len(changes), len(stream)))
+    if _, err := r.changeBeamModel(ctx, &plan, append(changes, secrets...)); err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Update Amenesik Beam",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    plan.ID = state.ID
+    plan.State = state.State
+    plan.ModelName = state.ModelName
+    plan.setDocument(stream)
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    resp.Diagnostics.Append(setBeamStream(ctx, resp.Private.SetKey, stream)...)
+    tflog.Info(ctx,"AMENESIK:BEAM LEAVE:UPDATE: SUCCESS");
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -197,19 +962,35 @@ func (r *beamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
     template := state.Template.String()
     program  := state.Program.String()
 
-    var br *BeamResponse
-
-    // DELETE the BEAM model now
-    br, err = r.client.DeleteBeamModel(ctx, template, program )
-    if err != nil {
+    // DELETE the BEAM model now, which may already have been deleted
+    _, err = r.client.DeleteBeamModel(ctx, template, program )
+    if err != nil && !errors.Is(err, ErrBeamNotFound) {
 	tflog.Info(ctx,"AMENESIK:BEAM ERROR: DELETE BEAM MODEL: "+err.Error());
+        resp.Diagnostics.AddError(
+            "Unable to Delete Amenesik Beam",
+            "Amenesik Client Error: "+err.Error(),
+        )
         return
     }
-    br.status = ""
     tflog.Info(ctx,"AMENESIK:BEAM LEAVE:DELETE: SUCCESS");
     return
 }
 
+// ImportState imports an existing beam by its template/program identifier.
+func (r *beamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    parts := strings.Split(req.ID, "/")
+    if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+        resp.Diagnostics.AddError(
+            "Unexpected Import Identifier",
+            fmt.Sprintf("Expected an import identifier of the form template/program, got: %q", req.ID),
+        )
+        return
+    }
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("template"), parts[0])...)
+    resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("program"), parts[1])...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *beamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
     // Add a nil check when handling ProviderData because Terraform
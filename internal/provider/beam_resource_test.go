@@ -0,0 +1,1062 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+func TestAccBeamResource(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	var changes int
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		// write-only attributes require Terraform 1.11
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: provider + testAccBeamResourceConfig("First", "s3cret", 1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("id"), knownvalue.StringExact("webtemplate/store")),
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("model_name"), knownvalue.StringExact("webstore")),
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("state"), knownvalue.StringExact("created")),
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("secret_data"), knownvalue.Null()),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckBeamData(sim, "webstore", "tag.Title", "First"),
+					testAccCheckBeamData(sim, "webstore", "node.2.db.PASS", "s3cret"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "amenesik_beam.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"domain", "region", "category", "param", "data", "secret_data_version", "last_updated",
+					"tosca_yaml", "topology_dot", "topology_mermaid",
+				},
+			},
+			// Update testing, only the changed path being applied
+			{
+				PreConfig: func() { changes = sim.Requests("change") },
+				Config:    provider + testAccBeamResourceConfig("Second", "s3cret", 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckBeamData(sim, "webstore", "tag.Title", "Second"),
+					testAccCheckBeamData(sim, "webstore", "node.2.db.PASS", "s3cret"),
+					testAccCheckBeamChanges(sim, &changes, 1),
+				),
+			},
+			// a new secret data version replaces the beam with the new secret data
+			{
+				Config: provider + testAccBeamResourceConfig("Second", "n3wer", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "node.2.db.PASS", "n3wer"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBeamResource_failedCreate(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			// a change of the cloned model fails
+			{
+				PreConfig:   func() { sim.Fail("change", 500) },
+				Config:      provider + testAccBeamResourceDataConfig("First"),
+				ExpectError: regexp.MustCompile(`Unable to Create Amenesik Beam`),
+			},
+			// the tainted beam is replaced by the next apply
+			{
+				Config: provider + testAccBeamResourceDataConfig("First"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("state"), knownvalue.StringExact("created")),
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "tag.Title", "First"),
+			},
+		},
+	})
+}
+
+func TestAccBeamResource_drift(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccBeamResourceDataConfig("First"),
+			},
+			// the model deleted outside of Terraform is created again
+			{
+				PreConfig:          func() { sim.DeleteModel("webstore") },
+				Config:             provider + testAccBeamResourceDataConfig("First"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: provider + testAccBeamResourceDataConfig("First"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "tag.Title", "First"),
+			},
+			// the value changed outside of Terraform is refreshed and changed back
+			{
+				PreConfig: func() {
+					m, _ := sim.Model("webstore")
+					m.Data["tag.Title"] = "Changed"
+					sim.SetModel(m)
+				},
+				Config: provider + testAccBeamResourceDataConfig("First"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "tag.Title", "First"),
+			},
+		},
+	})
+}
+
+func TestAccBeamResource_assignedModelName(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			// the name of a model of a template not ending in "template" is assigned by ACE
+			{
+				Config: provider + testAccBeamResourceTemplateConfig("webbase", "First"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("amenesik_beam.test", tfjsonpath.New("model_name")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("model_name"), knownvalue.StringExact("beam1")),
+				},
+				Check: testAccCheckBeamData(sim, "beam1", "tag.Title", "First"),
+			},
+			// and is kept by updates
+			{
+				Config: provider + testAccBeamResourceTemplateConfig("webbase", "Second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("amenesik_beam.test", tfjsonpath.New("model_name"), knownvalue.StringExact("beam1")),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "beam1", "tag.Title", "Second"),
+			},
+		},
+	})
+}
+
+func TestAccBeamResource_undefinedProbe(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			// a probe tag naming no probe of the account is refused by the plan
+			{
+				Config:      provider + testAccBeamResourceDataConfig("First", `{ path = "tag.Probe", value = "disk" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Undefined BEAM Probe`),
+			},
+			{
+				PreConfig: func() { sim.AddProbe("disk") },
+				Config:    provider + testAccBeamResourceDataConfig("First", `{ path = "tag.Probe", value = "disk" }`),
+				Check:     testAccCheckBeamData(sim, "webstore", "tag.Probe", "disk"),
+			},
+		},
+	})
+}
+
+func TestAccBeamResource_replace(t *testing.T) {
+	sim, provider := testAccSimulator(t)
+	sim.AddProbe("disk")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(sim),
+		Steps: []resource.TestStep{
+			{
+				Config: provider + testAccBeamResourceDataConfig("First"),
+			},
+			// a positional change cannot be applied again to the model
+			{
+				Config: provider + testAccBeamResourceDataConfig("First", `{ path = "tag.Probe", value = "disk" }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "tag.Probe", "disk"),
+			},
+			// nor can a path be removed from the model
+			{
+				Config: provider + testAccBeamResourceDataConfig("First"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("amenesik_beam.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: testAccCheckBeamData(sim, "webstore", "tag.Probe", ""),
+			},
+		},
+	})
+}
+
+func testAccBeamResourceConfig(title string, password string, version int) string {
+	return fmt.Sprintf(`
+resource "amenesik_beam" "test" {
+  template = "webtemplate"
+  program  = "store"
+  domain   = "example.com"
+  category = "amazonec2"
+  region   = "france"
+  param    = "none"
+  data = [
+    { path = "tag.Title", value = %[1]q },
+    { path = "node.1.name", value = "hwn1" },
+    { path = "node.1.type", value = "Compute" },
+    { path = "node.2.name", value = "swn2" },
+    { path = "node.2.base", value = "hwn1" },
+    { path = "node.2.type", value = "Database" },
+  ]
+  secret_data = [
+    { path = "node.2.db.PASS", value = %[2]q },
+  ]
+  secret_data_version = %[3]d
+}
+`, title, password, version)
+}
+
+func testAccBeamResourceDataConfig(title string, data ...string) string {
+	return testAccBeamResourceTemplateConfig("webtemplate", title, data...)
+}
+
+func testAccBeamResourceTemplateConfig(template string, title string, data ...string) string {
+	return fmt.Sprintf(`
+resource "amenesik_beam" "test" {
+  template = %[2]q
+  program  = "store"
+  domain   = "example.com"
+  category = "amazonec2"
+  region   = "france"
+  param    = "none"
+  data = [
+    { path = "tag.Title", value = %[1]q },
+    { path = "node.1.name", value = "hwn1" },
+    { path = "node.1.type", value = "Compute" },
+    %[3]s
+  ]
+}
+`, title, template, strings.Join(data, ",\n    "))
+}
+
+// testAccCheckBeamChanges verifies the number of changes applied since the count.
+func testAccCheckBeamChanges(sim *simulator.Simulator, count *int, expected int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if changes := sim.Requests("change") - *count; changes != expected {
+			return fmt.Errorf("expected %d changes to be applied, got %d", expected, changes)
+		}
+		return nil
+	}
+}
+
+// testAccCheckBeamData verifies a value changed in the simulated model.
+func testAccCheckBeamData(sim *simulator.Simulator, name string, path string, value string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		m, ok := sim.Model(name)
+		if !ok {
+			return fmt.Errorf("the model %s does not exist", name)
+		}
+		if m.Data[path] != value {
+			return fmt.Errorf("the value of %s of the model %s is %q rather than %q", path, name, m.Data[path], value)
+		}
+		return nil
+	}
+}
+
+func TestBeamResourceCheckNodeTypes(t *testing.T) {
+	tests := map[string]struct {
+		file     types.String
+		data     []BeamChange
+		derived  int
+		status   int
+		lists    int
+		warnings []path.Path
+	}{
+		"known types": {
+			data:  testBeamData("node.1.type", "Compute", "node.2.type", "tosca.nodes.Database", "node.2.base", "1"),
+			lists: 1,
+		},
+		"local type": {
+			data: testBeamData("type.1.name", "CompanyMySql", "node.1.type", "Compute", "node.2.type", "CompanyMySql"),
+		},
+		"compute nodes only": {
+			data: testBeamData("node.1.type", "Compute"),
+		},
+		"unknown type": {
+			data:     testBeamData("node.1.type", "Compute", "node.2.name", "db", "node.2.type", "Unknown"),
+			lists:    1,
+			warnings: []path.Path{path.Root("data").AtListIndex(2)},
+		},
+		"unknown derived type": {
+			file:     types.StringValue("shop.yaml"),
+			data:     testBeamData("node.1.type", "Unknown", "node.2.type", "Other", "node.2.name", "b"),
+			derived:  1,
+			lists:    1,
+			warnings: []path.Path{path.Root("source_file"), path.Root("data").AtListIndex(0)},
+		},
+		"node types not listed": {
+			data:   testBeamData("node.1.type", "Unknown"),
+			status: http.StatusInternalServerError,
+			lists:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lists := 0
+			c := testClient(t, func(req map[string]string) (int, string) {
+				if req["action"] != "list" || req["subject"] != "type" {
+					return http.StatusBadRequest, "unexpected request"
+				}
+				lists++
+				if test.status != 0 {
+					return test.status, "failure"
+				}
+				return http.StatusOK, `{"status":"200","result":[{"name":"Database"},{"name":"tosca.nodes.WebServer"}]}`
+			})
+			r := &beamResource{client: c}
+			diags := r.checkNodeTypes(context.Background(), test.file, test.data, test.derived)
+			if diags.HasError() || lists != test.lists {
+				t.Fatalf("expected %d lists and no errors, got %d lists and %v", test.lists, lists, diags)
+			}
+			var warnings []path.Path
+			for _, d := range diags.Warnings() {
+				warnings = append(warnings, d.(diag.DiagnosticWithPath).Path())
+			}
+			if fmt.Sprint(warnings) != fmt.Sprint(test.warnings) {
+				t.Errorf("expected the warnings at %v, got %v", test.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestBeamResourceCheckProbeTags(t *testing.T) {
+	tests := map[string]struct {
+		file    types.String
+		data    []BeamChange
+		derived int
+		status  int
+		lists   int
+		errors  []path.Path
+	}{
+		"document probe": {
+			data: testBeamData("tag.Probe", "disk", "probe.1.name", "disk"),
+		},
+		"account probe": {
+			data:  testBeamData("tag.Probe", "memory"),
+			lists: 1,
+		},
+		"undefined probes": {
+			data:   testBeamData("tag.Probe", "memory", "tag.Probe", "cpu", "node.1.type", "Compute", "tag.Probe", "swap"),
+			lists:  1,
+			errors: []path.Path{path.Root("data").AtListIndex(1), path.Root("data").AtListIndex(3)},
+		},
+		"undefined derived probe": {
+			file:    types.StringValue("shop.yaml"),
+			data:    testBeamData("tag.Probe", "cpu", "tag.Probe", "swap"),
+			derived: 1,
+			lists:   1,
+			errors:  []path.Path{path.Root("source_file"), path.Root("data").AtListIndex(0)},
+		},
+		"probes not listed": {
+			data:   testBeamData("tag.Probe", "cpu"),
+			status: http.StatusInternalServerError,
+			lists:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lists := 0
+			c := testClient(t, func(req map[string]string) (int, string) {
+				if req["action"] != "list" || req["subject"] != "probe" {
+					return http.StatusBadRequest, "unexpected request"
+				}
+				lists++
+				if test.status != 0 {
+					return test.status, "failure"
+				}
+				return http.StatusOK, `{"status":"200","result":[{"name":"memory"},{"name":"disk"}]}`
+			})
+			r := &beamResource{client: c}
+			diags := r.checkProbeTags(context.Background(), test.file, test.data, test.derived)
+			if diags.WarningsCount() != 0 || lists != test.lists {
+				t.Fatalf("expected %d lists and no warnings, got %d lists and %v", test.lists, lists, diags)
+			}
+			var paths []path.Path
+			for _, d := range diags.Errors() {
+				paths = append(paths, d.(diag.DiagnosticWithPath).Path())
+			}
+			if fmt.Sprint(paths) != fmt.Sprint(test.errors) {
+				t.Errorf("expected the errors at %v, got %v", test.errors, paths)
+			}
+		})
+	}
+}
+
+// the type of the elements of the data and secret_data lists
+var testBeamChangeType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"path": tftypes.String, "value": tftypes.String}}
+
+// testBeamChanges returns the list value of the path and value pairs.
+func testBeamChanges(pairs ...string) tftypes.Value {
+	var items []tftypes.Value
+	for i := 0; i+1 < len(pairs); i += 2 {
+		items = append(items, tftypes.NewValue(testBeamChangeType, map[string]tftypes.Value{
+			"path":  tftypes.NewValue(tftypes.String, pairs[i]),
+			"value": tftypes.NewValue(tftypes.String, pairs[i+1]),
+		}))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: testBeamChangeType}, items)
+}
+
+func TestBeamSecrets(t *testing.T) {
+	tests := map[string]struct {
+		attributes map[string]tftypes.Value
+		expected   []BeamChange
+	}{
+		"no secrets": {},
+		"secrets": {
+			attributes: map[string]tftypes.Value{
+				"data":        testBeamChanges("node.2.db.USER", "shop"),
+				"secret_data": testBeamChanges("node.2.db.PASS", "s3cret-db-pass", "node.3.api.KEY", "s3cret-api-key"),
+			},
+			expected: testBeamData("node.2.db.PASS", "s3cret-db-pass", "node.3.api.KEY", "s3cret-api-key"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secrets, diags := beamSecrets(context.Background(), testResourceConfig(NewBeamResource(), test.attributes))
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if fmt.Sprint(secrets) != fmt.Sprint(test.expected) {
+				t.Errorf("expected the secrets %v, got %v", test.expected, secrets)
+			}
+		})
+	}
+}
+
+func TestMaskBeamSecrets(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = maskBeamSecrets(ctx, testBeamData("node.2.db.PASS", "s3cret-db-pass", "node.3.api.KEY", "s3cret-api-key", "node.4.app.PASS", "mypass"))
+	tflog.Info(ctx, "AMENESIK:BEAM CHANGE: node.2.db.PASS:s3cret-db-pass", map[string]interface{}{"data": "node.3.api.KEY:s3cret-api-key"})
+	c := &Client{account: "test"}
+	tflog.SubsystemInfo(c.logContext(ctx), clientLogSubsystem, "AMENESIK:ACE: CHANGE BEAM MODEL: s3cret-db-pass")
+	tflog.SubsystemInfo(c.logContext(ctx), clientLogSubsystem, "AMENESIK:ACE: CHANGE BEAM MODEL: node.4.app.PASS:mypass")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 log entries, got %v", entries)
+	}
+	if strings.Contains(output.String(), "s3cret") || !strings.Contains(fmt.Sprint(entries), "node.2.db.PASS:***") {
+		t.Errorf("expected the secret values to be masked, got %v", entries)
+	}
+	if strings.Contains(output.String(), "mypass") || !strings.Contains(fmt.Sprint(entries), "node.4.app.PASS:***") {
+		t.Errorf("expected the short secret value to be masked, got %v", entries)
+	}
+}
+
+func TestBeamResourceValidateConfigSecrets(t *testing.T) {
+	tests := map[string]struct {
+		data   tftypes.Value
+		secret tftypes.Value
+		errors []path.Path
+	}{
+		"valid secrets": {
+			data:   testBeamChanges("node.1.name", "db", "node.1.type", "Database"),
+			secret: testBeamChanges("node.1.db.PASS", "s3cret-db-pass"),
+		},
+		"undefined secret node": {
+			data:   testBeamChanges("node.1.name", "db", "node.1.type", "Database"),
+			secret: testBeamChanges("node.1.db.PASS", "s3cret-db-pass", "node.1.base", "missing"),
+			errors: []path.Path{path.Root("secret_data").AtListIndex(1)},
+		},
+		"undefined data node": {
+			data:   testBeamChanges("node.1.name", "db", "node.2.base", "missing"),
+			secret: testBeamChanges("node.1.db.PASS", "s3cret-db-pass"),
+			errors: []path.Path{path.Root("data").AtListIndex(1)},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := testResourceConfig(NewBeamResource(), map[string]tftypes.Value{"data": test.data, "secret_data": test.secret})
+			var resp fwresource.ValidateConfigResponse
+			NewBeamResource().(fwresource.ResourceWithValidateConfig).ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{Config: config}, &resp)
+			var errors []path.Path
+			for _, d := range resp.Diagnostics.Errors() {
+				errors = append(errors, d.(diag.DiagnosticWithPath).Path())
+			}
+			if fmt.Sprint(errors) != fmt.Sprint(test.errors) {
+				t.Errorf("expected the errors at %v, got %v", test.errors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDiffBeamStream(t *testing.T) {
+	prior := testBeamData(
+		"tag.Title", "First",
+		"node.1.name", "hwn1",
+		"node.1.type", "Compute",
+		"tag.Probe", "disk",
+		"node.1.host.num_cpus", "2",
+	)
+	tests := map[string]struct {
+		prior   []BeamChange
+		planned []BeamChange
+		changes []BeamChange
+		replace bool
+	}{
+		"unchanged": {
+			prior:   prior,
+			planned: prior,
+		},
+		"changed value": {
+			prior:   prior,
+			planned: testBeamData("tag.Title", "Second", "node.1.name", "hwn1", "node.1.type", "Compute", "tag.Probe", "disk", "node.1.host.num_cpus", "4"),
+			changes: testBeamData("tag.Title", "Second", "node.1.host.num_cpus", "4"),
+		},
+		"added path": {
+			prior:   prior,
+			planned: append(testBeamData("node.1.host.mem_size", "8"), prior...),
+			changes: testBeamData("node.1.host.mem_size", "8"),
+		},
+		"white space in paths": {
+			prior:   prior,
+			planned: testBeamData("tag . Title", "First", "node.1.name", "hwn1", "node . 1 . type", "Compute", "tag.Probe", "disk", "node.1.host.num_cpus", "2"),
+		},
+		"last value of a repeated path": {
+			prior:   testBeamData("tag.Title", "First", "tag.Title", "Second"),
+			planned: testBeamData("tag.Title", "Third", "tag.Title", "Second"),
+		},
+		"repeated path changed": {
+			prior:   testBeamData("tag.Title", "First"),
+			planned: testBeamData("tag.Title", "Second", "tag.Title", "Third"),
+			changes: testBeamData("tag.Title", "Third"),
+		},
+		"removed path": {
+			prior:   prior,
+			planned: prior[:4],
+			replace: true,
+		},
+		"added probe tag": {
+			prior:   prior,
+			planned: append(testBeamData("tag.Probe", "memory"), prior...),
+			replace: true,
+		},
+		"changed probe tag": {
+			prior:   prior,
+			planned: testBeamData("tag.Title", "First", "node.1.name", "hwn1", "node.1.type", "Compute", "tag.Probe", "cpu", "node.1.host.num_cpus", "2"),
+			replace: true,
+		},
+		"added copy": {
+			prior:   prior,
+			planned: append(testBeamData("copy.node", "hwn1"), prior...),
+			replace: true,
+		},
+		"added port": {
+			prior:   prior,
+			planned: append(testBeamData("node.1.endpoint.tcp_port", "443"), prior...),
+			replace: true,
+		},
+		"path changed before a copy": {
+			prior:   testBeamData("node.1.name", "web", "node.1.host.num_cpus", "2", "copy.node", "web"),
+			planned: testBeamData("node.1.name", "web", "node.1.host.num_cpus", "4", "copy.node", "web"),
+			replace: true,
+		},
+		"path changed after a copy": {
+			prior:   testBeamData("node.1.name", "web", "copy.node", "web", "node.2.host.num_cpus", "2"),
+			planned: testBeamData("node.1.name", "web", "copy.node", "web", "node.2.host.num_cpus", "4"),
+			changes: testBeamData("node.2.host.num_cpus", "4"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes, reason := diffBeamStream(test.prior, test.planned)
+			if (reason != "") != test.replace {
+				t.Fatalf("expected the replacement %v, got %q", test.replace, reason)
+			}
+			if !reflect.DeepEqual(changes, test.changes) {
+				t.Errorf("expected the changes %v, got %v", test.changes, changes)
+			}
+		})
+	}
+}
+
+// testBeamResourcePlan plans the configuration of the beam resource of the
+// provider server, returning the object type of the resource and the plan.
+func testBeamResourcePlan(t *testing.T, server tfprotov6.ProviderServer, prior tftypes.Value, private []byte, config map[string]tftypes.Value) (tftypes.Object, *tfprotov6.PlanResourceChangeResponse) {
+	t.Helper()
+	ctx := context.Background()
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectType := schema.ResourceSchemas["amenesik_beam"].ValueType().(tftypes.Object)
+
+	// the proposed state keeps the computed values of the prior state
+	proposed := map[string]tftypes.Value{}
+	if prior.IsNull() {
+		prior = tftypes.NewValue(objectType, nil)
+	} else {
+		var values map[string]tftypes.Value
+		if err := prior.As(&values); err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range values {
+			proposed[name] = value
+		}
+	}
+	for name, value := range config {
+		if name != "secret_data" {
+			proposed[name] = value
+		}
+	}
+	plan, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "amenesik_beam",
+		PriorState:       testDynamicValue(t, objectType, prior),
+		ProposedNewState: testDynamicValue(t, objectType, testObjectValue(objectType, proposed)),
+		Config:           testDynamicValue(t, objectType, testObjectValue(objectType, config)),
+		PriorPrivate:     private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return objectType, plan
+}
+
+// testDynamicValue returns the dynamic value of the value of the object type.
+func testDynamicValue(t *testing.T, objectType tftypes.Object, value tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+	v, err := tfprotov6.NewDynamicValue(objectType, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v
+}
+
+// testBeamResourceChange plans and applies the configuration of the beam
+// resource of the provider server, returning the resulting state, private
+// state and whether the plan replaces the beam.
+func testBeamResourceChange(t *testing.T, server tfprotov6.ProviderServer, prior tftypes.Value, private []byte, config map[string]tftypes.Value) (tftypes.Value, []byte, bool) {
+	t.Helper()
+	ctx := context.Background()
+	objectType, plan := testBeamResourcePlan(t, server, prior, private, config)
+	if prior.IsNull() {
+		prior = tftypes.NewValue(objectType, nil)
+	}
+	dynamic := func(value tftypes.Value) *tfprotov6.DynamicValue {
+		return testDynamicValue(t, objectType, value)
+	}
+	if len(plan.Diagnostics) != 0 {
+		t.Fatalf("unexpected plan diagnostics %v", plan.Diagnostics)
+	}
+	if len(plan.RequiresReplace) != 0 {
+		return prior, private, true
+	}
+
+	apply, err := server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "amenesik_beam",
+		PriorState:     dynamic(prior),
+		PlannedState:   plan.PlannedState,
+		Config:         dynamic(testObjectValue(objectType, config)),
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range apply.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected apply diagnostic %s: %s", d.Summary, d.Detail)
+		}
+	}
+	state, err := apply.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state, apply.Private, false
+}
+
+func TestBeamResourceUpdate(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	for _, probe := range []string{"disk", "memory", "cpu"} {
+		sim.AddProbe(probe)
+	}
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+	config := func(data tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"template":            tftypes.NewValue(tftypes.String, "webtemplate"),
+			"program":             tftypes.NewValue(tftypes.String, "store"),
+			"domain":              tftypes.NewValue(tftypes.String, "example.com"),
+			"region":              tftypes.NewValue(tftypes.String, "france"),
+			"category":            tftypes.NewValue(tftypes.String, "amazonec2"),
+			"param":               tftypes.NewValue(tftypes.String, "none"),
+			"data":                data,
+			"secret_data":         testBeamChanges("node.1.db.PASS", "s3cret-db-pass"),
+			"secret_data_version": tftypes.NewValue(tftypes.Number, 1),
+		}
+	}
+	data := []string{"tag.Title", "First", "node.1.name", "hwn1", "node.1.type", "Database", "tag.Probe", "disk"}
+
+	state, private, _ := testBeamResourceChange(t, server, tftypes.NewValue(tftypes.Object{}, nil), nil, config(testBeamChanges(data...)))
+	if changes := sim.Requests("change"); changes != 5 {
+		t.Fatalf("expected the creation to apply 5 changes, got %d", changes)
+	}
+
+	// only the changed and added paths are applied, without the secret data
+	data[1] = "Second"
+	data = append(data, "node.1.host.num_cpus", "2")
+	state, private, replaced := testBeamResourceChange(t, server, state, private, config(testBeamChanges(data...)))
+	if replaced {
+		t.Fatal("unexpected replacement of the updated beam")
+	}
+	if changes := sim.Requests("change"); changes != 7 {
+		t.Errorf("expected the update to apply 2 changes, got %d", changes-5)
+	}
+	if m, _ := sim.Model("webstore"); m.Data["tag.Title"] != "Second" || m.Data["node.1.host.num_cpus"] != "2" || m.Data["node.1.db.PASS"] != "s3cret-db-pass" {
+		t.Errorf("unexpected model data %v", m.Data)
+	}
+
+	// removed and positional paths replace the beam
+	for name, data := range map[string][]string{
+		"removed path":      data[:8],
+		"added probe tag":   append(append([]string{}, data...), "tag.Probe", "memory"),
+		"changed probe tag": {"tag.Title", "Second", "node.1.name", "hwn1", "node.1.type", "Database", "tag.Probe", "cpu", "node.1.host.num_cpus", "2"},
+	} {
+		if _, _, replaced := testBeamResourceChange(t, server, state, private, config(testBeamChanges(data...))); !replaced {
+			t.Errorf("%s: expected the replacement of the beam", name)
+		}
+	}
+	changed := config(testBeamChanges(data...))
+	changed["param"] = tftypes.NewValue(tftypes.String, "other")
+	if _, _, replaced := testBeamResourceChange(t, server, state, private, changed); !replaced {
+		t.Error("param: expected the replacement of the beam")
+	}
+	if changes := sim.Requests("change"); changes != 7 {
+		t.Errorf("expected no further changes, got %d", changes-7)
+	}
+}
+
+func TestBeamResourcePlanProbeTags(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	sim.AddProbe("disk")
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+	tests := map[string]struct {
+		probe tftypes.Value
+		error bool
+	}{
+		"account probe":   {probe: tftypes.NewValue(tftypes.String, "disk")},
+		"undefined probe": {probe: tftypes.NewValue(tftypes.String, "cpu"), error: true},
+		"unknown probe":   {probe: tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := tftypes.NewValue(tftypes.List{ElementType: testBeamChangeType}, []tftypes.Value{
+				tftypes.NewValue(testBeamChangeType, map[string]tftypes.Value{
+					"path":  tftypes.NewValue(tftypes.String, "tag.Probe"),
+					"value": test.probe,
+				}),
+			})
+			_, plan := testBeamResourcePlan(t, server, tftypes.NewValue(tftypes.Object{}, nil), nil, map[string]tftypes.Value{
+				"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+				"program":  tftypes.NewValue(tftypes.String, "store"),
+				"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+				"region":   tftypes.NewValue(tftypes.String, "france"),
+				"category": tftypes.NewValue(tftypes.String, "amazonec2"),
+				"data":     data,
+			})
+			var summaries []string
+			for _, d := range plan.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					summaries = append(summaries, d.Summary+" at "+d.Attribute.String())
+				}
+			}
+			expected := []string(nil)
+			if test.error {
+				expected = []string{"Undefined BEAM Probe at AttributeName(\"data\").ElementKeyInt(0)"}
+			}
+			if !reflect.DeepEqual(summaries, expected) {
+				t.Errorf("expected the plan errors %v, got %v", expected, summaries)
+			}
+		})
+	}
+}
+
+// testBeamResourceRead reads the beam resource of the provider server,
+// returning the refreshed state and private state.
+func testBeamResourceRead(t *testing.T, server tfprotov6.ProviderServer, state tftypes.Value, private []byte) (tftypes.Value, []byte) {
+	t.Helper()
+	ctx := context.Background()
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectType := schema.ResourceSchemas["amenesik_beam"].ValueType().(tftypes.Object)
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "amenesik_beam",
+		CurrentState: testDynamicValue(t, objectType, state),
+		Private:      private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range read.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected read diagnostic %s: %s", d.Summary, d.Detail)
+		}
+	}
+	refreshed, err := read.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return refreshed, read.Private
+}
+
+// testBeamStateValue returns the value of the attribute of the beam state.
+func testBeamStateValue(t *testing.T, state tftypes.Value, name string) tftypes.Value {
+	t.Helper()
+	var values map[string]tftypes.Value
+	if err := state.As(&values); err != nil {
+		t.Fatal(err)
+	}
+	return values[name]
+}
+
+func TestBeamResourceDrift(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+	data := testBeamChanges("tag.Title", "First", "node.1.name", "hwn1", "node.1.type", "Compute", "node.1.host.num_cpus", "2")
+	config := map[string]tftypes.Value{
+		"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+		"program":  tftypes.NewValue(tftypes.String, "store"),
+		"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+		"region":   tftypes.NewValue(tftypes.String, "france"),
+		"category": tftypes.NewValue(tftypes.String, "amazonec2"),
+		"param":    tftypes.NewValue(tftypes.String, "none"),
+		"data":     data,
+	}
+	state, private, _ := testBeamResourceChange(t, server, tftypes.NewValue(tftypes.Object{}, nil), nil, config)
+
+	// an unchanged model leaves the state unchanged
+	if refreshed, _ := testBeamResourceRead(t, server, state, private); !testBeamStateValue(t, refreshed, "data").Equal(data) {
+		t.Fatalf("unexpected refreshed data %v", testBeamStateValue(t, refreshed, "data"))
+	}
+
+	// the value changed outside of Terraform is refreshed
+	m, _ := sim.Model("webstore")
+	m.Data["node.1.host.num_cpus"] = "4"
+	sim.SetModel(m)
+	refreshed, private := testBeamResourceRead(t, server, state, private)
+	drifted := testBeamChanges("tag.Title", "First", "node.1.name", "hwn1", "node.1.type", "Compute", "node.1.host.num_cpus", "4")
+	if got := testBeamStateValue(t, refreshed, "data"); !got.Equal(drifted) {
+		t.Errorf("expected the refreshed data %v, got %v", drifted, got)
+	}
+	var tosca string
+	if err := testBeamStateValue(t, refreshed, "tosca_yaml").As(&tosca); err != nil || !strings.Contains(tosca, "num_cpus: 4") {
+		t.Errorf("expected the refreshed document, got %q", tosca)
+	}
+
+	// the next apply changes the value back in place
+	changes := sim.Requests("change")
+	if _, _, replaced := testBeamResourceChange(t, server, refreshed, private, config); replaced {
+		t.Fatal("unexpected replacement of the drifted beam")
+	}
+	if n := sim.Requests("change") - changes; n != 1 {
+		t.Errorf("expected 1 change, got %d", n)
+	}
+	if m, _ := sim.Model("webstore"); m.Data["node.1.host.num_cpus"] != "2" {
+		t.Errorf("expected the value to be changed back, got %v", m.Data)
+	}
+}
+
+func TestBeamResourceReplacePaths(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+	config := func(data tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+			"program":  tftypes.NewValue(tftypes.String, "store"),
+			"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+			"region":   tftypes.NewValue(tftypes.String, "france"),
+			"category": tftypes.NewValue(tftypes.String, "amazonec2"),
+			"param":    tftypes.NewValue(tftypes.String, "none"),
+			"data":     data,
+		}
+	}
+	state, private, _ := testBeamResourceChange(t, server, tftypes.NewValue(tftypes.Object{}, nil), nil, config(testBeamChanges("tag.Title", "First", "node.1.name", "hwn1")))
+
+	// the removed path replaces the beam through the data list only
+	_, plan := testBeamResourcePlan(t, server, state, private, config(testBeamChanges("tag.Title", "First")))
+	var paths []string
+	for _, p := range plan.RequiresReplace {
+		paths = append(paths, p.String())
+	}
+	sort.Strings(paths)
+	expected := []string{`AttributeName("data")`, `AttributeName("source_content")`, `AttributeName("source_file")`}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected the replacement paths %v, got %v", expected, paths)
+	}
+}
+
+func TestRefreshBeamStream(t *testing.T) {
+	stream := testBeamData("tag.Title", "First", "node.1.name", "hwn1", "node . 1 . host.num_cpus", "2", "tag.Probe", "disk", "node.hwn1.host.mem_size", "4")
+	tests := map[string]struct {
+		exported []BeamChange
+		expected []BeamChange
+		drifted  bool
+	}{
+		"unchanged": {
+			exported: testBeamData("tag.Title", "First", "node.1.name", "hwn1", "node.1.host.num_cpus", "2", "tag.Probe", "disk"),
+			expected: stream,
+		},
+		"changed value": {
+			exported: testBeamData("tag.Title", "Changed", "node.1.name", "hwn1", "node.1.host.num_cpus", "4"),
+			expected: testBeamData("tag.Title", "Changed", "node.1.name", "hwn1", "node . 1 . host.num_cpus", "4", "tag.Probe", "disk", "node.hwn1.host.mem_size", "4"),
+			drifted:  true,
+		},
+		"missing value": {
+			exported: testBeamData("node.1.name", "hwn1"),
+			expected: stream,
+		},
+		"positional and named paths": {
+			exported: testBeamData("tag.Probe", "cpu", "node.1.host.mem_size", "8"),
+			expected: stream,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			refreshed, drifted := refreshBeamStream(stream, test.exported)
+			if drifted != test.drifted || !reflect.DeepEqual(refreshed, test.expected) {
+				t.Errorf("expected %v %t, got %v %t", test.expected, test.drifted, refreshed, drifted)
+			}
+		})
+	}
+	if stream[0].Value != "First" {
+		t.Error("expected the stream to be left unchanged")
+	}
+}
+
+func TestBeamResourceCreateUndefinedProbe(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	c, err := NewClient(context.Background(), sim.URL(), "test", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the probe tag only known during apply is checked by create
+	r := testResourceConfigure(t, NewBeamResource(), c)
+	attributes := map[string]tftypes.Value{
+		"template": tftypes.NewValue(tftypes.String, "webtemplate"),
+		"program":  tftypes.NewValue(tftypes.String, "store"),
+		"domain":   tftypes.NewValue(tftypes.String, "example.com"),
+		"region":   tftypes.NewValue(tftypes.String, "france"),
+		"category": tftypes.NewValue(tftypes.String, "amazonec2"),
+		"data":     testBeamChanges("tag.Probe", "cpu"),
+	}
+	resp := fwresource.CreateResponse{State: testResourceNullState(r)}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testResourcePlan(r, attributes), Config: testResourceConfig(r, attributes)}, &resp)
+	if got := testResourceError(resp.Diagnostics); got != "Undefined BEAM Probe" {
+		t.Errorf("unexpected error %q", got)
+	}
+	if clones := sim.Requests("clone"); clones != 0 {
+		t.Errorf("expected no model to be cloned, got %d clones", clones)
+	}
+}
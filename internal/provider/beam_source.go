@@ -0,0 +1,337 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// BASMATI ENHANCED APPLICATION MODEL (BEAM)
+// -------------------------------------------
+// Derivation of the BEAM data stream, being
+// the ordered collection of path/value pairs
+// of a BEAM resource, from an existing TOSCA
+// or BEAM service template YAML document, as
+// exported from the ACE console or rendered
+// by the tosca_yaml attribute.
+// -------------------------------------------
+
+package provider
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+// ---------------------------------
+// A parsed YAML document value
+// ---------------------------------
+type yamlValue struct {
+    Scalar string
+    Keys   []string
+    Map    map[string]*yamlValue
+    List   []*yamlValue
+}
+
+// returns the value of a mapping key, nil when absent
+func (v *yamlValue) Get(key string) *yamlValue {
+    if v == nil || v.Map == nil {
+        return nil
+    }
+    return v.Map[key]
+}
+
+// returns the scalar text of the value, the empty string otherwise
+func (v *yamlValue) Text() string {
+    if v == nil {
+        return ""
+    }
+    return v.Scalar
+}
+
+// the depth of the nested and aliased values of a YAML document
+const yamlMaxDepth = 100
+
+// ----------------------------------------------------------
+// PARSE YAML
+// ----------------------------------------------------------
+// Parses a TOSCA service template YAML document into its
+// mappings, in the order of their keys, sequences and scalars,
+// aliases being replaced by their anchored values and nulls by
+// empty scalars. Only a single document is accepted.
+// ----------------------------------------------------------
+func ParseYaml(content string) (*yamlValue, error) {
+    decoder := yaml.NewDecoder(strings.NewReader(content))
+    var doc yaml.Node
+    if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+        return &yamlValue{}, nil
+    } else if err != nil {
+        return nil, err
+    }
+    var next yaml.Node
+    if err := decoder.Decode(&next); !errors.Is(err, io.EOF) {
+        if err != nil {
+            return nil, err
+        }
+        return nil, fmt.Errorf("line %d: only a single YAML document is supported", next.Line)
+    }
+    return yamlNodeValue(&doc, 0)
+}
+
+// converts a YAML node to the value of its content
+func yamlNodeValue(n *yaml.Node, depth int) (*yamlValue, error) {
+    if depth > yamlMaxDepth {
+        return nil, fmt.Errorf("line %d: the values are nested too deeply", n.Line)
+    }
+    switch n.Kind {
+    case yaml.DocumentNode:
+        if len(n.Content) == 0 {
+            return &yamlValue{}, nil
+        }
+        return yamlNodeValue(n.Content[0], depth+1)
+    case yaml.AliasNode:
+        return yamlNodeValue(n.Alias, depth+1)
+    case yaml.MappingNode:
+        v := &yamlValue{Map: map[string]*yamlValue{}}
+        for i := 0; i+1 < len(n.Content); i += 2 {
+            key := n.Content[i]
+            if key.Kind != yaml.ScalarNode {
+                return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+            }
+            if _, ok := v.Map[key.Value]; ok {
+                return nil, fmt.Errorf("line %d: duplicate mapping key %q", key.Line, key.Value)
+            }
+            item, err := yamlNodeValue(n.Content[i+1], depth+1)
+            if err != nil {
+                return nil, err
+            }
+            v.Keys = append(v.Keys, key.Value)
+            v.Map[key.Value] = item
+        }
+        return v, nil
+    case yaml.SequenceNode:
+        v := &yamlValue{List: []*yamlValue{}}
+        for _, node := range n.Content {
+            item, err := yamlNodeValue(node, depth+1)
+            if err != nil {
+                return nil, err
+            }
+            v.List = append(v.List, item)
+        }
+        return v, nil
+    }
+    if n.ShortTag() == "!!null" {
+        return &yamlValue{}, nil
+    }
+    return &yamlValue{Scalar: n.Value}, nil
+}
+
+// ---------------------------------
+// BEAM data stream builder
+// ---------------------------------
+type beamStreamBuilder struct {
+    data []BeamChange
+    err  error
+}
+
+func (b *beamStreamBuilder) add(p string, v string) {
+    b.data = append(b.data, BeamChange{Path: p, Value: v})
+}
+
+// adds a property whose value may be a scalar or a sequence of scalars,
+// the first property of any other value being recorded as the error
+func (b *beamStreamBuilder) property(p string, v *yamlValue) {
+    values := []*yamlValue{v}
+    if v.List != nil {
+        values = v.List
+    }
+    for _, item := range values {
+        if item.Map != nil || item.List != nil {
+            if b.err == nil {
+                b.err = fmt.Errorf("the property %s is not a scalar or a sequence of scalars", p)
+            }
+            return
+        }
+        b.add(p, item.Text())
+    }
+}
+
+// returns the BEAM name of a TOSCA node or type name
+func beamType(t string) string {
+    return strings.TrimPrefix(t, toscaNodePrefix)
+}
+
+// returns the node named by a requirement, written as a name or with a node key
+func requirementNode(v *yamlValue) string {
+    if n := v.Get("node"); n != nil {
+        return n.Text()
+    }
+    return v.Text()
+}
+
+// returns the script of a lifecycle operation, written as a value or with an implementation key
+func operationScript(v *yamlValue) string {
+    if i := v.Get("implementation"); i != nil {
+        if p := i.Get("primary"); p != nil {
+            return p.Text()
+        }
+        return i.Text()
+    }
+    return v.Text()
+}
+
+// ------------------------------------------------------------
+// BEAM SOURCE CHANGES
+// ------------------------------------------------------------
+// Derives the BEAM data stream equivalent to a TOSCA or BEAM
+// service template document. Node names are all declared
+// before any properties so that bases and relations may refer
+// to nodes regardless of their order in the document:
+//
+// - metadata               to tag.<name>
+// - imports                to import
+// - node_types             to type.<n>.<property>
+// - node_templates         to node.<n>.<capability>.<property>
+// - host requirements      to node.<n>.base
+// - relationship_templates to relation.node.<source>.<subject>
+// - monitoring policies    to probe.<n>.<property>
+// ------------------------------------------------------------
+func BeamSourceChanges(content string) ([]BeamChange, error) {
+    doc, err := ParseYaml(content)
+    if err != nil {
+        return nil, err
+    }
+    if doc.Map == nil {
+        return nil, fmt.Errorf("the document is not a TOSCA service template")
+    }
+    b := &beamStreamBuilder{}
+
+    if meta := doc.Get("metadata"); meta != nil {
+        for _, k := range meta.Keys {
+            b.property("tag."+k, meta.Map[k])
+        }
+    }
+
+    if imports := doc.Get("imports"); imports != nil {
+        for _, i := range imports.List {
+            if i.Map != nil && len(i.Keys) > 0 {
+                i = i.Map[i.Keys[0]]
+                if f := i.Get("file"); f != nil {
+                    i = f
+                }
+            }
+            b.add("import", i.Text())
+        }
+    }
+
+    if nodeTypes := doc.Get("node_types"); nodeTypes != nil {
+        for n, name := range nodeTypes.Keys {
+            t := nodeTypes.Map[name]
+            at := "type." + strconv.Itoa(n+1) + "."
+            b.add(at+"name", beamType(name))
+            if props := t.Get("properties"); props != nil {
+                for _, k := range props.Keys {
+                    b.property(at+k, props.Map[k])
+                }
+            }
+            standard := t.Get("interfaces").Get("Standard")
+            if standard != nil {
+                for _, k := range standard.Keys {
+                    b.add(at+k, operationScript(standard.Map[k]))
+                }
+            }
+        }
+    }
+
+    topology := doc.Get("topology_template")
+    templates := topology.Get("node_templates")
+    relations := topology.Get("relationship_templates")
+    if templates != nil {
+        for n, name := range templates.Keys {
+            b.add("node."+strconv.Itoa(n+1)+".name", name)
+        }
+        for n, name := range templates.Keys {
+            node := templates.Map[name]
+            at := "node." + strconv.Itoa(n+1) + "."
+            if t := node.Get("type"); t != nil {
+                b.add(at+"type", beamType(t.Text()))
+            }
+            if d := node.Get("description"); d != nil {
+                b.add(at+"description", d.Text())
+            }
+            if props := node.Get("properties"); props != nil {
+                for _, k := range props.Keys {
+                    b.property(at+k, props.Map[k])
+                }
+            }
+            if caps := node.Get("capabilities"); caps != nil {
+                for _, c := range caps.Keys {
+                    props := caps.Map[c].Get("properties")
+                    if props == nil {
+                        continue
+                    }
+                    for _, k := range props.Keys {
+                        b.property(at+c+"."+k, props.Map[k])
+                    }
+                }
+            }
+            reqs := node.Get("requirements")
+            if reqs == nil {
+                continue
+            }
+            for _, q := range reqs.List {
+                for _, k := range q.Keys {
+                    source := requirementNode(q.Map[k])
+                    switch {
+                    case k == "host":
+                        b.add(at+"base", source)
+                    case relations.Get(source) != nil:
+                        // described by the relationship template
+                    case templates.Get(source) != nil:
+                        b.add("relation.node."+source+".hostname", "node."+name)
+                    }
+                }
+            }
+        }
+    }
+
+    if relations != nil {
+        for _, name := range relations.Keys {
+            props := relations.Map[name].Get("properties")
+            source, target := props.Get("source").Text(), props.Get("target").Text()
+            if source == "" || target == "" {
+                return nil, fmt.Errorf("the relationship template %s has no source or target property", name)
+            }
+            subject := props.Get("subject").Text()
+            if subject == "" {
+                subject = "hostname"
+            }
+            b.add("relation.node."+source+"."+subject, "node."+target)
+        }
+    }
+
+    if policies := topology.Get("policies"); policies != nil {
+        n := 0
+        for _, item := range policies.List {
+            for _, name := range item.Keys {
+                t := item.Map[name].Get("type").Text()
+                if t != "" && !strings.HasSuffix(t, "Monitoring") {
+                    continue
+                }
+                props := item.Map[name].Get("properties")
+                n++
+                at := "probe." + strconv.Itoa(n) + "."
+                b.add(at+"name", name)
+                if props == nil {
+                    continue
+                }
+                for _, k := range props.Keys {
+                    b.property(at+k, props.Map[k])
+                }
+            }
+        }
+    }
+    if b.err != nil {
+        return nil, b.err
+    }
+    return b.data, nil
+}
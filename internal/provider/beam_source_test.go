@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testYamlPlain returns the plain Go form of a parsed YAML value.
+func testYamlPlain(v *yamlValue) interface{} {
+	switch {
+	case v.Map != nil:
+		m := map[string]interface{}{}
+		for _, k := range v.Keys {
+			m[k] = testYamlPlain(v.Map[k])
+		}
+		return m
+	case v.List != nil:
+		l := []interface{}{}
+		for _, item := range v.List {
+			l = append(l, testYamlPlain(item))
+		}
+		return l
+	}
+	return v.Scalar
+}
+
+func TestParseYaml(t *testing.T) {
+	tests := map[string]struct {
+		content  string
+		expected interface{}
+	}{
+		"empty": {
+			content:  "# only a comment\n---\n",
+			expected: "",
+		},
+		"scalar": {
+			content:  "web",
+			expected: "web",
+		},
+		"nested mapping": {
+			content:  "a:\n  b: 1\n  c:\n    d: two\n",
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": "1", "c": map[string]interface{}{"d": "two"}}},
+		},
+		"sequences": {
+			content:  "a:\n  - 1\n  - 2\nb:\n- x\n- y: z\n  w: v\n",
+			expected: map[string]interface{}{"a": []interface{}{"1", "2"}, "b": []interface{}{"x", map[string]interface{}{"y": "z", "w": "v"}}},
+		},
+		"dash on its own line": {
+			content:  "a:\n  -\n    b: c\n",
+			expected: map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": "c"}}},
+		},
+		"quoted scalars and keys": {
+			content:  "\"a b\": \"x: y\"\n'c': 'it''s'\nd: \"tab\\t\" # comment\ne: \"#not a comment\"\n",
+			expected: map[string]interface{}{"a b": "x: y", "c": "it's", "d": "tab\t", "e": "#not a comment"},
+		},
+		"block scalars": {
+			content:  "a: |\n  one\n  two\nb: >-\n  one\n  two\n",
+			expected: map[string]interface{}{"a": "one\ntwo\n", "b": "one two"},
+		},
+		"multi-line block scalar": {
+			content:  "a: |\n  #!/bin/sh\n\n  if true; then\n    echo yes\n  fi\nb: 1\n",
+			expected: map[string]interface{}{"a": "#!/bin/sh\n\nif true; then\n  echo yes\nfi\n", "b": "1"},
+		},
+		"anchors and aliases": {
+			content:  "a: &port 80\nb: *port\nc: &node\n  d: x\ne: *node\n",
+			expected: map[string]interface{}{"a": "80", "b": "80", "c": map[string]interface{}{"d": "x"}, "e": map[string]interface{}{"d": "x"}},
+		},
+		"tags": {
+			content:  "a: !!str 1\n",
+			expected: map[string]interface{}{"a": "1"},
+		},
+		"flow collections": {
+			content:  "a: [1, \"2, 3\", [4]]\nb: {x: 1, y: {z: 2}}\nc: [1, 2,]\nd: []\n",
+			expected: map[string]interface{}{"a": []interface{}{"1", "2, 3", []interface{}{"4"}}, "b": map[string]interface{}{"x": "1", "y": map[string]interface{}{"z": "2"}}, "c": []interface{}{"1", "2"}, "d": []interface{}{}},
+		},
+		"null values": {
+			content:  "a: ~\nb: null\nc:\n",
+			expected: map[string]interface{}{"a": "", "b": "", "c": ""},
+		},
+		"windows line endings": {
+			content:  "a: 1\r\nb: 2\r\n",
+			expected: map[string]interface{}{"a": "1", "b": "2"},
+		},
+		"end of document": {
+			content:  "a: 1\n...\n",
+			expected: map[string]interface{}{"a": "1"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := ParseYaml(test.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := testYamlPlain(v); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %#v, got %#v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseYamlMalformed(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		err     string
+	}{
+		"tab indentation":           {"a:\n\tb: 1\n", "line 2: found character that cannot start any token"},
+		"duplicate key":             {"a: 1\na: 2\n", "line 2: duplicate mapping key \"a\""},
+		"unexpected indentation":    {"a:\n    b: 1\n  c: 2\n", "did not find expected key"},
+		"expected key":              {"a: 1\nb\n", "could not find expected ':'"},
+		"unknown alias":             {"a: *x\n", "unknown anchor 'x' referenced"},
+		"recursive alias":           {"a: &x\n  b: *x\n", "line 2: the values are nested too deeply"},
+		"complex key":               {"? [a, b]\n: 1\n", "line 1: mapping keys must be scalars"},
+		"multiple documents":        {"a: 1\n---\nb: 2\n", "only a single YAML document is supported"},
+		"content after the end":     {"a: 1\n...\nb: 2\n", "did not find expected <document start>"},
+		"unterminated sequence":     {"a: [1, 2\n", "did not find expected ',' or ']'"},
+		"unterminated mapping":      {"a: {x: 1\n", "did not find expected ',' or '}'"},
+		"empty mapping entry":       {"a: {x: 1,,y: 2}\n", "did not find expected node content"},
+		"empty sequence entry":      {"a: [1,,2]\n", "did not find expected node content"},
+		"unterminated double quote": {"a: \"x\n", "found unexpected end of stream"},
+		"unterminated single quote": {"a: 'x\n", "found unexpected end of stream"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseYaml(test.content)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected the error %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestBeamSourceChanges(t *testing.T) {
+	content := `tosca_definitions_version: tosca_simple_yaml_1_0
+metadata:
+  Title: Shop
+imports:
+  - Database
+  - mysql:
+      file: CompanyMySql
+node_types:
+  tosca.nodes.CompanyMySql:
+    properties:
+      version: 8
+    interfaces:
+      Standard:
+        start:
+          implementation:
+            primary: start.sh
+topology_template:
+  node_templates:
+    web:
+      type: tosca.nodes.WebServer
+      requirements:
+        - host:
+            node: hwn1
+        - database: db
+    hwn1:
+      type: tosca.nodes.Compute
+      capabilities:
+        firewall:
+          properties:
+            tcp_port: [80, 443]
+    db:
+      type: tosca.nodes.Database
+      description: |
+        The shop database.
+
+          Replicated nightly.
+      requirements:
+        - host: hwn1
+  policies:
+    - memory:
+        type: tosca.policies.Monitoring
+        properties:
+          threshold: 90
+    - scaling:
+        type: tosca.policies.Scaling
+`
+	expected := testBeamData(
+		"tag.Title", "Shop",
+		"import", "Database",
+		"import", "CompanyMySql",
+		"type.1.name", "CompanyMySql",
+		"type.1.version", "8",
+		"type.1.start", "start.sh",
+		"node.1.name", "web",
+		"node.2.name", "hwn1",
+		"node.3.name", "db",
+		"node.1.type", "WebServer",
+		"node.1.base", "hwn1",
+		"relation.node.db.hostname", "node.web",
+		"node.2.type", "Compute",
+		"node.2.firewall.tcp_port", "80",
+		"node.2.firewall.tcp_port", "443",
+		"node.3.type", "Database",
+		"node.3.description", "The shop database.\n\n  Replicated nightly.\n",
+		"node.3.base", "hwn1",
+		"probe.1.name", "memory",
+		"probe.1.threshold", "90",
+	)
+	data, err := BeamSourceChanges(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, data)
+	}
+}
+
+func TestBeamSourceChangesMalformed(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		err     string
+	}{
+		"not a mapping":           {"- a\n- b\n", "the document is not a TOSCA service template"},
+		"scalar":                  {"web\n", "the document is not a TOSCA service template"},
+		"empty flow entry":        {"a: {x: 1,,y: 2}", "yaml: did not find expected node content"},
+		"nested property":         {"metadata:\n  Title:\n    en: Shop\n", "the property tag.Title is not a scalar or a sequence of scalars"},
+		"nested property item":    {"topology_template:\n  node_templates:\n    web:\n      properties:\n        tcp_port:\n          - 80\n          - range: 8000\n", "the property node.1.tcp_port is not a scalar or a sequence of scalars"},
+		"relation without target": {"topology_template:\n  relationship_templates:\n    r1:\n      properties:\n        source: a\n", "the relationship template r1 has no source or target property"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := BeamSourceChanges(test.content)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected the error %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+// TestBeamSourceRoundTrip verifies that a rendered TOSCA document derives
+// a data stream compiling to the same document.
+func TestBeamSourceRoundTrip(t *testing.T) {
+	doc, _ := CompileBeamData(testBeamData(
+		"tag.Title", "Shop: web",
+		"tag.Probe", "disk",
+		"import", "Database",
+		"type.1.name", "CompanyMySql",
+		"type.1.version", "8",
+		"type.1.start", "start.sh",
+		"node.1.name", "hwn1",
+		"node.1.type", "Compute",
+		"node.1.host.num_cpus", "2",
+		"node.1.firewall.tcp_port", "80",
+		"node.1.firewall.tcp_port", "443",
+		"node.2.name", "swn2",
+		"node.2.type", "Database",
+		"node.2.base", "hwn1",
+		"node.2.description", "line one\nline two",
+		"node.3.name", "web",
+		"node.3.base", "hwn1",
+		"relation.node.3.contract", "swn2",
+		"probe.1.name", "disk",
+		"probe.1.threshold", "90",
+	))
+	rendered := RenderBeamTosca(doc)
+	data, err := BeamSourceChanges(rendered)
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, rendered)
+	}
+	again, issues := CompileBeamData(data)
+	for _, issue := range issues {
+		if !issue.Warning {
+			t.Errorf("unexpected issue %v", issue)
+		}
+	}
+	if got := RenderBeamTosca(again); got != rendered {
+		t.Errorf("expected:\n%s\ngot:\n%s", rendered, got)
+	}
+	if strings.Count(rendered, "tcp_port") != 1 {
+		t.Errorf("expected a single multiple valued property:\n%s", rendered)
+	}
+}
@@ -0,0 +1,154 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// BASMATI ENHANCED APPLICATION MODEL (BEAM)
+// -------------------------------------------
+// Rendering of the topology of the typed BEAM
+// document model as Graphviz DOT and Mermaid
+// flowchart diagrams. Each hardware node forms
+// a group holding its software node layers and
+// relations link the hardware node groups.
+// -------------------------------------------
+
+package provider
+
+import (
+    "strconv"
+    "strings"
+)
+
+// ---------------------------------
+// A BEAM topology layout
+// ---------------------------------
+type beamTopology struct {
+    doc    *BeamDocument
+    ids    map[*BeamNode]string
+    roots  []*BeamNode
+    layers map[*BeamNode][]*BeamNode
+}
+
+// returns the hardware node at the root of the layering of a node
+func rootNode(n *BeamNode) *BeamNode {
+    seen := map[*BeamNode]bool{}
+    for n.base != nil && !seen[n] {
+        seen[n] = true
+        n = n.base
+    }
+    return n
+}
+
+// prepares the layout of the nodes of a BEAM document
+func newBeamTopology(doc *BeamDocument) *beamTopology {
+    t := &beamTopology{doc: doc, ids: map[*BeamNode]string{}, layers: map[*BeamNode][]*BeamNode{}}
+    for i, n := range doc.Nodes {
+        t.ids[n] = "n" + strconv.Itoa(i+1)
+        root := rootNode(n)
+        if root == n {
+            t.roots = append(t.roots, n)
+        } else {
+            t.layers[root] = append(t.layers[root], n)
+        }
+    }
+    return t
+}
+
+// returns the label of a node, being its name and type
+func (t *beamTopology) label(n *BeamNode) string {
+    if n.Type == "" {
+        return t.doc.NodeName(n)
+    }
+    return t.doc.NodeName(n) + "\n" + n.Type
+}
+
+// returns a DOT double quoted string
+func dotString(v string) string {
+    r := strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+    return "\"" + r.Replace(v) + "\""
+}
+
+// -------------------------------------------------------
+// RENDER BEAM DOT
+// -------------------------------------------------------
+// Renders the topology of the BEAM document as a Graphviz
+// DOT digraph, hardware nodes being boxes, software nodes
+// ellipses, base layering dashed edges and the relations
+// solid edges labelled with their subject.
+// -------------------------------------------------------
+func RenderBeamDot(doc *BeamDocument) string {
+    t := newBeamTopology(doc)
+    var sb strings.Builder
+    sb.WriteString("digraph beam {\n")
+    sb.WriteString("  rankdir=LR;\n")
+    sb.WriteString("  node [fontname=\"Helvetica\"];\n")
+    for _, root := range t.roots {
+        layers := t.layers[root]
+        indent := "  "
+        if len(layers) > 0 {
+            sb.WriteString("  subgraph cluster_" + t.ids[root] + " {\n")
+            sb.WriteString("    label=" + dotString(doc.NodeName(root)) + ";\n")
+            sb.WriteString("    style=rounded;\n")
+            indent = "    "
+        }
+        sb.WriteString(indent + t.ids[root] + " [label=" + dotString(t.label(root)) + ", shape=box];\n")
+        for _, n := range layers {
+            sb.WriteString(indent + t.ids[n] + " [label=" + dotString(t.label(n)) + ", shape=ellipse];\n")
+        }
+        if len(layers) > 0 {
+            sb.WriteString("  }\n")
+        }
+    }
+    for _, n := range doc.Nodes {
+        if n.base != nil {
+            sb.WriteString("  " + t.ids[n] + " -> " + t.ids[n.base] + " [style=dashed, label=\"base\"];\n")
+        }
+    }
+    for _, r := range doc.Relations {
+        sb.WriteString("  " + t.ids[r.Source] + " -> " + t.ids[r.Target] + " [label=" + dotString(r.Subject) + "];\n")
+    }
+    sb.WriteString("}\n")
+    return sb.String()
+}
+
+// returns a Mermaid quoted label
+func mermaidString(v string) string {
+    r := strings.NewReplacer("\"", "#quot;", "\n", "<br/>")
+    return "\"" + r.Replace(v) + "\""
+}
+
+// -------------------------------------------------------
+// RENDER BEAM MERMAID
+// -------------------------------------------------------
+// Renders the topology of the BEAM document as a Mermaid
+// flowchart, hardware nodes being rectangles within the
+// subgraph of their software layers, software nodes being
+// rounded, base layering dotted links and the relations
+// solid links labelled with their subject.
+// -------------------------------------------------------
+func RenderBeamMermaid(doc *BeamDocument) string {
+    t := newBeamTopology(doc)
+    var sb strings.Builder
+    sb.WriteString("flowchart LR\n")
+    for _, root := range t.roots {
+        layers := t.layers[root]
+        indent := "  "
+        if len(layers) > 0 {
+            sb.WriteString("  subgraph g" + t.ids[root] + " [" + mermaidString(doc.NodeName(root)) + "]\n")
+            indent = "    "
+        }
+        sb.WriteString(indent + t.ids[root] + "[" + mermaidString(t.label(root)) + "]\n")
+        for _, n := range layers {
+            sb.WriteString(indent + t.ids[n] + "(" + mermaidString(t.label(n)) + ")\n")
+        }
+        if len(layers) > 0 {
+            sb.WriteString("  end\n")
+        }
+    }
+    for _, n := range doc.Nodes {
+        if n.base != nil {
+            sb.WriteString("  " + t.ids[n] + " -. base .-> " + t.ids[n.base] + "\n")
+        }
+    }
+    for _, r := range doc.Relations {
+        sb.WriteString("  " + t.ids[r.Source] + " -- " + r.Subject + " --> " + t.ids[r.Target] + "\n")
+    }
+    return sb.String()
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+    "context"
+    "github.com/hashicorp/terraform-plugin-framework/attr"
+    "github.com/hashicorp/terraform-plugin-framework/function"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ function.Function = &beamTopologyFunction{}
+)
+
+// NewBeamTopologyFunction is a helper function to simplify the provider implementation.
+func NewBeamTopologyFunction() function.Function {
+    return &beamTopologyFunction{}
+}
+
+// beam topology function is the function implementation.
+type beamTopologyFunction struct{}
+
+func (f *beamTopologyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+    resp.Name = "beam_topology"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *beamTopologyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+    resp.Definition = function.Definition{
+        Summary:     "Render the topology of BEAM data",
+        Description: "Renders the nodes, base layering and relations described by a BEAM data list as a Graphviz DOT or Mermaid diagram.",
+        Parameters: []function.Parameter{
+            function.ListParameter{
+                Name:        "data",
+                Description: "The BEAM data list of path and value objects, as for the amenesik_beam resource.",
+                ElementType: types.ObjectType{
+                    AttrTypes: map[string]attr.Type{
+                        "path":  types.StringType,
+                        "value": types.StringType,
+                    },
+                },
+            },
+            function.StringParameter{
+                Name:        "format",
+                Description: "The diagram format, either dot or mermaid.",
+            },
+        },
+        Return: function.StringReturn{},
+    }
+}
+
+// ------------------------------------------------
+// RUN BEAM TOPOLOGY FUNCTION
+// ------------------------------------------------
+// Compiles the BEAM data list and renders the
+// resulting topology in the requested format, as
+// for the topology attributes of the BEAM resource.
+// ------------------------------------------------
+func (f *beamTopologyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+    var items []beamChangeModel
+    var format string
+    resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &items, &format))
+    if resp.Error != nil {
+        return
+    }
+
+    doc, _ := CompileBeamData(beamChanges(items))
+    var result string
+    switch format {
+    case "dot":
+        result = RenderBeamDot(doc)
+    case "mermaid":
+        result = RenderBeamMermaid(doc)
+    default:
+        resp.Error = function.NewArgumentFuncError(1, "The format must be either dot or mermaid.")
+        return
+    }
+    resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testBeamTopologyData layers two software nodes on a hardware node related to a second one.
+var testBeamTopologyData = testBeamData(
+	"node.1.name", "hwn1",
+	"node.1.type", "Compute",
+	"node.2.name", "swn2",
+	"node.2.type", "Database",
+	"node.2.base", "hwn1",
+	"node.3.name", `web "front"`,
+	"node.3.base", "swn2",
+	"node.4.type", "Compute",
+	"relation.node.3.contract", "node.4",
+)
+
+// testBeamCycleData has nodes that are the bases of each other.
+var testBeamCycleData = testBeamData(
+	"node.1.name", "a",
+	"node.2.name", "b",
+	"node.2.base", "a",
+	"node.1.base", "b",
+)
+
+func TestRenderBeamDot(t *testing.T) {
+	tests := map[string]struct {
+		data     []BeamChange
+		expected string
+	}{
+		"empty": {
+			expected: `digraph beam {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+}
+`,
+		},
+		"layers and relations": {
+			data: testBeamTopologyData,
+			expected: `digraph beam {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+  subgraph cluster_n1 {
+    label="hwn1";
+    style=rounded;
+    n1 [label="hwn1\nCompute", shape=box];
+    n2 [label="swn2\nDatabase", shape=ellipse];
+    n3 [label="web \"front\"", shape=ellipse];
+  }
+  n4 [label="node-4\nCompute", shape=box];
+  n2 -> n1 [style=dashed, label="base"];
+  n3 -> n2 [style=dashed, label="base"];
+  n3 -> n4 [label="contract"];
+}
+`,
+		},
+		"base cycle": {
+			data: testBeamCycleData,
+			expected: `digraph beam {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+  n1 [label="a", shape=box];
+  n2 [label="b", shape=box];
+  n1 -> n2 [style=dashed, label="base"];
+  n2 -> n1 [style=dashed, label="base"];
+}
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, _ := CompileBeamData(test.data)
+			if got := RenderBeamDot(doc); got != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderBeamMermaid(t *testing.T) {
+	tests := map[string]struct {
+		data     []BeamChange
+		expected string
+	}{
+		"empty": {
+			expected: "flowchart LR\n",
+		},
+		"layers and relations": {
+			data: testBeamTopologyData,
+			expected: `flowchart LR
+  subgraph gn1 ["hwn1"]
+    n1["hwn1<br/>Compute"]
+    n2("swn2<br/>Database")
+    n3("web #quot;front#quot;")
+  end
+  n4["node-4<br/>Compute"]
+  n2 -. base .-> n1
+  n3 -. base .-> n2
+  n3 -- contract --> n4
+`,
+		},
+		"base cycle": {
+			data: testBeamCycleData,
+			expected: `flowchart LR
+  n1["a"]
+  n2["b"]
+  n1 -. base .-> n2
+  n2 -. base .-> n1
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, _ := CompileBeamData(test.data)
+			if got := RenderBeamMermaid(doc); got != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestBeamTopologyFunction(t *testing.T) {
+	elementType := types.ObjectType{AttrTypes: map[string]attr.Type{"path": types.StringType, "value": types.StringType}}
+	item := types.ObjectValueMust(elementType.AttrTypes, map[string]attr.Value{
+		"path":  types.StringValue("node.1.name"),
+		"value": types.StringValue("hwn1"),
+	})
+	data := types.ListValueMust(elementType, []attr.Value{item})
+
+	tests := map[string]struct {
+		format   string
+		expected string
+		err      bool
+	}{
+		"dot":     {format: "dot", expected: "digraph beam {\n  rankdir=LR;\n  node [fontname=\"Helvetica\"];\n  n1 [label=\"hwn1\", shape=box];\n}\n"},
+		"mermaid": {format: "mermaid", expected: "flowchart LR\n  n1[\"hwn1\"]\n"},
+		"svg":     {format: "svg", err: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{data, types.StringValue(test.format)})}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			NewBeamTopologyFunction().Run(context.Background(), req, &resp)
+			if test.err {
+				if resp.Error == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.String).ValueString(); got != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
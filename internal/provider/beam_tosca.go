@@ -0,0 +1,256 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// BASMATI ENHANCED APPLICATION MODEL (BEAM)
+// -------------------------------------------
+// Rendering of the typed BEAM document model
+// as the TOSCA service template YAML document
+// of which BEAM is a conforming derivation.
+// -------------------------------------------
+
+package provider
+
+import (
+    "strconv"
+    "strings"
+)
+
+// the TOSCA version of the rendered documents
+const toscaVersion = "tosca_simple_yaml_1_0"
+
+// the TOSCA prefix of BEAM node and type names
+const toscaNodePrefix = "tosca.nodes."
+
+// the lifecycle operations of BEAM node types
+var toscaOperations = []string{"create", "start", "stop", "save", "delete"}
+
+// ---------------------------------
+// A minimal YAML document writer
+// ---------------------------------
+type yamlWriter struct {
+    sb strings.Builder
+}
+
+// writes a key with a nested block to follow
+func (w *yamlWriter) block(depth int, key string) {
+    w.sb.WriteString(strings.Repeat("  ", depth) + yamlScalar(key) + ":\n")
+}
+
+// writes a key and its scalar value
+func (w *yamlWriter) value(depth int, key string, v string) {
+    w.sb.WriteString(strings.Repeat("  ", depth) + yamlScalar(key) + ": " + yamlScalar(v) + "\n")
+}
+
+// writes a scalar sequence item
+func (w *yamlWriter) item(depth int, v string) {
+    w.sb.WriteString(strings.Repeat("  ", depth) + "- " + yamlScalar(v) + "\n")
+}
+
+// writes a sequence item holding a single key and scalar value
+func (w *yamlWriter) pair(depth int, key string, v string) {
+    w.sb.WriteString(strings.Repeat("  ", depth) + "- " + yamlScalar(key) + ": " + yamlScalar(v) + "\n")
+}
+
+// writes a property collection, multiple valued properties becoming sequences
+func (w *yamlWriter) properties(depth int, properties []BeamProperty) {
+    done := map[string]bool{}
+    for _, p := range properties {
+        if done[p.Name] {
+            continue
+        }
+        done[p.Name] = true
+        if !multiValued(p.Name) {
+            w.value(depth, p.Name, p.Value)
+            continue
+        }
+        w.block(depth, p.Name)
+        for _, q := range properties {
+            if q.Name == p.Name {
+                w.item(depth+1, q.Value)
+            }
+        }
+    }
+}
+
+// ----------------------------------------------------
+// YAML SCALAR
+// ----------------------------------------------------
+// Returns the plain scalar when it cannot be mistaken
+// for YAML syntax, otherwise the double quoted scalar.
+// ----------------------------------------------------
+func yamlScalar(v string) string {
+    if v == "" || v != strings.TrimSpace(v) || strings.ContainsAny(v, "\"'#{}[],&*!|>%@`\n\t\\") ||
+        strings.Contains(v, ": ") || strings.HasSuffix(v, ":") || strings.HasPrefix(v, "- ") || v == "-" {
+        return strconv.Quote(v)
+    }
+    switch strings.ToLower(v) {
+    case "true", "false", "yes", "no", "on", "off", "null", "~":
+        return strconv.Quote(v)
+    }
+    return v
+}
+
+// returns the TOSCA name of a BEAM node type
+func toscaType(t string) string {
+    if t == "" || strings.Contains(t, ".") {
+        return t
+    }
+    return toscaNodePrefix + t
+}
+
+// --------------------------------------------------------
+// RENDER BEAM TOSCA
+// --------------------------------------------------------
+// Renders the BEAM document as a TOSCA service template:
+//
+// - TAGS      as metadata, probe tags forming a sequence
+// - IMPORTS   as imports
+// - TYPES     as node_types with Standard interfaces
+// - NODES     as node_templates, bases as host requirements
+// - RELATIONS as relationship_templates and connections
+// - PROBES    as monitoring policies
+// --------------------------------------------------------
+func RenderBeamTosca(doc *BeamDocument) string {
+    w := &yamlWriter{}
+    w.value(0, "tosca_definitions_version", toscaVersion)
+
+    if len(doc.Tags) > 0 {
+        w.block(0, "metadata")
+        var probes []string
+        for _, t := range doc.Tags {
+            if t.Name == "Probe" {
+                probes = append(probes, t.Value)
+            } else {
+                w.value(1, t.Name, t.Value)
+            }
+        }
+        if len(probes) > 0 {
+            w.block(1, "Probe")
+            for _, p := range probes {
+                w.item(2, p)
+            }
+        }
+    }
+
+    if len(doc.Imports) > 0 {
+        w.block(0, "imports")
+        for _, i := range doc.Imports {
+            w.item(1, i)
+        }
+    }
+
+    if len(doc.Types) > 0 {
+        w.block(0, "node_types")
+        for _, t := range doc.Types {
+            if t.Name == "" {
+                continue
+            }
+            w.block(1, toscaType(t.Name))
+            w.value(2, "derived_from", "tosca.nodes.SoftwareComponent")
+            var operations, others []BeamProperty
+            for _, p := range t.Properties {
+                if isOperation(p.Name) {
+                    operations = append(operations, p)
+                } else {
+                    others = append(others, p)
+                }
+            }
+            if len(others) > 0 {
+                w.block(2, "properties")
+                w.properties(3, others)
+            }
+            if len(operations) > 0 {
+                w.block(2, "interfaces")
+                w.block(3, "Standard")
+                w.properties(4, operations)
+            }
+        }
+    }
+
+    w.block(0, "topology_template")
+    w.block(1, "node_templates")
+    for _, n := range doc.Nodes {
+        w.block(2, doc.NodeName(n))
+        if n.Type != "" {
+            w.value(3, "type", toscaType(n.Type))
+        }
+        if n.Description != "" {
+            w.value(3, "description", n.Description)
+        }
+        var capabilities []*BeamCapability
+        for _, c := range n.Capabilities {
+            if c.Name == "" {
+                w.block(3, "properties")
+                w.properties(4, c.Properties)
+            } else {
+                capabilities = append(capabilities, c)
+            }
+        }
+        if len(capabilities) > 0 {
+            w.block(3, "capabilities")
+            for _, c := range capabilities {
+                w.block(4, c.Name)
+                w.block(5, "properties")
+                w.properties(6, c.Properties)
+            }
+        }
+        var requirements [][2]string
+        if base := doc.BaseName(n); base != "" {
+            requirements = append(requirements, [2]string{"host", base})
+        }
+        for j, r := range doc.Relations {
+            if r.Target == n {
+                requirements = append(requirements, [2]string{"connection", relationName(j)})
+            }
+        }
+        if len(requirements) > 0 {
+            w.block(3, "requirements")
+            for _, q := range requirements {
+                w.pair(4, q[0], q[1])
+            }
+        }
+    }
+
+    if len(doc.Relations) > 0 {
+        w.block(1, "relationship_templates")
+        for j, r := range doc.Relations {
+            w.block(2, relationName(j))
+            w.value(3, "type", "tosca.relationships.ConnectsTo")
+            w.block(3, "properties")
+            w.value(4, "source", doc.NodeName(r.Source))
+            w.value(4, "target", doc.NodeName(r.Target))
+            w.value(4, "subject", r.Subject)
+        }
+    }
+
+    if len(doc.Probes) > 0 {
+        w.block(1, "policies")
+        for j, p := range doc.Probes {
+            name := p.Name
+            if name == "" {
+                name = "probe-" + strconv.Itoa(j+1)
+            }
+            w.sb.WriteString("    - " + yamlScalar(name) + ":\n")
+            w.value(4, "type", "tosca.policies.Monitoring")
+            if len(p.Properties) > 0 {
+                w.block(4, "properties")
+                w.properties(5, p.Properties)
+            }
+        }
+    }
+    return w.sb.String()
+}
+
+// returns the name of the relationship template of a relation
+func relationName(j int) string {
+    return "relation-" + strconv.Itoa(j+1)
+}
+
+// returns true when the node type property is a lifecycle operation
+func isOperation(name string) bool {
+    for _, o := range toscaOperations {
+        if o == name {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestYamlScalar(t *testing.T) {
+	tests := map[string]string{
+		"web":          "web",
+		"4:8:16":       "4:8:16",
+		"":             `""`,
+		" padded":      `" padded"`,
+		"Shop: web":    `"Shop: web"`,
+		"key:":         `"key:"`,
+		"- item":       `"- item"`,
+		"-":            `"-"`,
+		"yes":          `"yes"`,
+		"Off":          `"Off"`,
+		"null":         `"null"`,
+		"~":            `"~"`,
+		"#comment":     `"#comment"`,
+		"{a}":          `"{a}"`,
+		"a,b":          `"a,b"`,
+		"line\nbreak":  `"line\nbreak"`,
+		`back\slash`:   `"back\\slash"`,
+		`say "hello"`:  `"say \"hello\""`,
+		"http://a.b/c": "http://a.b/c",
+		"80-90":        "80-90",
+	}
+	for v, expected := range tests {
+		if got := yamlScalar(v); got != expected {
+			t.Errorf("yamlScalar(%q): expected %s, got %s", v, expected, got)
+		}
+	}
+}
+
+func TestRenderBeamTosca(t *testing.T) {
+	tests := map[string]struct {
+		data     []BeamChange
+		expected string
+	}{
+		"empty": {
+			expected: `tosca_definitions_version: tosca_simple_yaml_1_0
+topology_template:
+  node_templates:
+`,
+		},
+		"multiple valued properties": {
+			data: testBeamData(
+				"node.1.name", "web",
+				"node.1.type", "tosca.nodes.WebServer",
+				"node.1.firewall.tcp_port", "80",
+				"node.1.firewall.tcp_port", "443",
+				"node.2.description", "unnamed",
+			),
+			expected: `tosca_definitions_version: tosca_simple_yaml_1_0
+topology_template:
+  node_templates:
+    web:
+      type: tosca.nodes.WebServer
+      capabilities:
+        firewall:
+          properties:
+            tcp_port:
+              - 80
+              - 443
+    node-2:
+      description: unnamed
+`,
+		},
+		"complete document": {
+			data: testBeamData(
+				"tag.Title", "Shop: web",
+				"tag.Probe", "disk",
+				"import", "Database",
+				"type.1.name", "CompanyMySql",
+				"type.1.version", "8",
+				"type.1.start", "start.sh",
+				"node.1.name", "hwn1",
+				"node.1.type", "Compute",
+				"node.1.host.num_cpus", "2",
+				"node.2.name", "swn2",
+				"node.2.type", "Database",
+				"node.2.base", "hwn1",
+				"node.2.port", "3306",
+				"node.3.name", "web",
+				"node.3.base", "hwn1",
+				"relation.node.3.contract", "swn2",
+				"probe.1.name", "disk",
+				"probe.1.threshold", "90",
+			),
+			expected: `tosca_definitions_version: tosca_simple_yaml_1_0
+metadata:
+  Title: "Shop: web"
+  Probe:
+    - disk
+imports:
+  - Database
+node_types:
+  tosca.nodes.CompanyMySql:
+    derived_from: tosca.nodes.SoftwareComponent
+    properties:
+      version: 8
+    interfaces:
+      Standard:
+        start: start.sh
+topology_template:
+  node_templates:
+    hwn1:
+      type: tosca.nodes.Compute
+      capabilities:
+        host:
+          properties:
+            num_cpus: 2
+    swn2:
+      type: tosca.nodes.Database
+      properties:
+        port: 3306
+      requirements:
+        - host: hwn1
+        - connection: relation-1
+    web:
+      requirements:
+        - host: hwn1
+  relationship_templates:
+    relation-1:
+      type: tosca.relationships.ConnectsTo
+      properties:
+        source: web
+        target: swn2
+        subject: contract
+  policies:
+    - disk:
+        type: tosca.policies.Monitoring
+        properties:
+          threshold: 90
+`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, _ := CompileBeamData(test.data)
+			if got := RenderBeamTosca(doc); got != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, got)
+			}
+		})
+	}
+}
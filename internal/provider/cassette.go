@@ -0,0 +1,230 @@
+// -------------------------------------------
+// ACE CLIENT CASSETTES
+// -------------------------------------------
+// Records the requests of the ACE client and
+// the responses of the Amenesik Enterprise
+// Cloud to a cassette file, with credentials
+// scrubbed, and replays them deterministically
+// so that the exact sequence of responses of a
+// bug report becomes a regression test.
+// -------------------------------------------
+
+package provider
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "os"
+    "regexp"
+    "sort"
+    "strings"
+    "sync"
+
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// the replacement of the credentials and secret values of cassettes
+const cassetteRedacted = "REDACTED"
+
+// the credentials of JSON request and response bodies
+var cassetteSensitivePattern = regexp.MustCompile(`"(auth|secret|token|apikey)"(\s*:\s*)"[^"]*"`)
+
+// a request of the ACE client and the response it received
+type cassetteInteraction struct {
+    Method   string `json:"method"`
+    Path     string `json:"path"`
+    Request  string `json:"request"`
+    Status   int    `json:"status"`
+    Response string `json:"response"`
+}
+
+// returns the body with its credentials and secret values replaced, the
+// secrets of any length being replaced, the longest first, so that none
+// is left partially replaced by a shorter one
+func cassetteScrub(ctx context.Context, body string) string {
+    body = cassetteSensitivePattern.ReplaceAllString(body, `"$1"$2"`+cassetteRedacted+`"`)
+    secrets := append([]string{}, scrubSecrets(ctx)...)
+    sort.SliceStable(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+    for _, secret := range secrets {
+        body = strings.ReplaceAll(body, secret, cassetteRedacted)
+    }
+    return body
+}
+
+// ----------------------------------------------------------------------
+// NEW HTTP CLIENT ( )
+// ----------------------------------------------------------------------
+// Returns the HTTP client of the ACE client, recording every request and
+// response to the cassette file named by ACE_RECORD, or replaying those
+// of the cassette file named by ACE_REPLAY without any network access.
+// ----------------------------------------------------------------------
+func newHTTPClient(ctx context.Context) (*http.Client, error) {
+    record := os.Getenv("ACE_RECORD")
+    replay := os.Getenv("ACE_REPLAY")
+    switch {
+    case record != "" && replay != "":
+        return nil, errors.New("ACE_RECORD and ACE_REPLAY cannot both be set")
+    case record != "":
+        tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CASSETTE: RECORD: "+record)
+        return &http.Client{Transport: &cassetteRecorder{file: record, next: http.DefaultTransport}}, nil
+    case replay != "":
+        tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CASSETTE: REPLAY: "+replay)
+        player, err := newCassettePlayer(replay)
+        if err != nil {
+            return nil, err
+        }
+        return &http.Client{Transport: player}, nil
+    }
+    return &http.Client{}, nil
+}
+
+// ----------------------------------------------------------------------
+// CASSETTE RECORDER
+// ----------------------------------------------------------------------
+// Appends each interaction to the cassette file as a line of JSON, so
+// that the successive provider processes of a Terraform run add their
+// interactions to the same cassette. Credentials, being the auth, secret,
+// token and apikey members, and the secret values of the request context
+// are scrubbed, and headers, such as the Authorization header, are never
+// recorded.
+// ----------------------------------------------------------------------
+type cassetteRecorder struct {
+    mu   sync.Mutex
+    file string
+    next http.RoundTripper
+}
+
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+    request, err := readBody(&req.Body)
+    if err != nil {
+        return nil, err
+    }
+    resp, err := r.next.RoundTrip(req)
+    if err != nil {
+        return nil, err
+    }
+    response, err := readBody(&resp.Body)
+    if err != nil {
+        return nil, err
+    }
+
+    ctx := req.Context()
+    line, _ := json.Marshal(cassetteInteraction{
+        Method:   req.Method,
+        Path:     req.URL.Path,
+        Request:  cassetteScrub(ctx, request),
+        Status:   resp.StatusCode,
+        Response: cassetteScrub(ctx, response),
+    })
+    if err := r.append(append(line, '\n')); err != nil {
+        tflog.SubsystemWarn(ctx, clientLogSubsystem, "AMENESIK:ACE: CASSETTE: RECORD: "+err.Error())
+    }
+    return resp, nil
+}
+
+// appends the line to the cassette file
+func (r *cassetteRecorder) append(line []byte) error {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    f, err := os.OpenFile(r.file, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+    if err != nil {
+        return err
+    }
+    if _, err := f.Write(line); err != nil {
+        f.Close()
+        return err
+    }
+    return f.Close()
+}
+
+// reads the body, replacing it by a copy so that it may be read again
+func readBody(body *io.ReadCloser) (string, error) {
+    if *body == nil || *body == http.NoBody {
+        return "", nil
+    }
+    data, err := io.ReadAll(*body)
+    (*body).Close()
+    if err != nil {
+        return "", err
+    }
+    *body = io.NopCloser(bytes.NewReader(data))
+    return string(data), nil
+}
+
+// ----------------------------------------------------------------------
+// CASSETTE PLAYER
+// ----------------------------------------------------------------------
+// Answers each request with the response of the first interaction of the
+// cassette not yet replayed whose method, path and scrubbed request body
+// are those of the request, so that repeated requests, such as those
+// polling the status of an instance, receive the recorded responses in
+// order. Requests without such an interaction fail.
+// ----------------------------------------------------------------------
+type cassettePlayer struct {
+    mu           sync.Mutex
+    interactions []cassetteInteraction
+    replayed     []bool
+}
+
+// loads the interactions of the cassette file
+func newCassettePlayer(file string) (*cassettePlayer, error) {
+    f, err := os.Open(file)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open the cassette: %w", err)
+    }
+    defer f.Close()
+
+    p := &cassettePlayer{}
+    scanner := bufio.NewScanner(f)
+    scanner.Buffer(nil, 16*1024*1024)
+    for n := 1; scanner.Scan(); n++ {
+        if strings.TrimSpace(scanner.Text()) == "" {
+            continue
+        }
+        var i cassetteInteraction
+        if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+            return nil, fmt.Errorf("failed to read the cassette %s line %d: %w", file, n, err)
+        }
+        p.interactions = append(p.interactions, i)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("failed to read the cassette %s: %w", file, err)
+    }
+    p.replayed = make([]bool, len(p.interactions))
+    return p, nil
+}
+
+func (p *cassettePlayer) RoundTrip(req *http.Request) (*http.Response, error) {
+    request, err := readBody(&req.Body)
+    if err != nil {
+        return nil, err
+    }
+    request = cassetteScrub(req.Context(), request)
+
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    for n, i := range p.interactions {
+        if p.replayed[n] || i.Method != req.Method || i.Path != req.URL.Path || i.Request != request {
+            continue
+        }
+        p.replayed[n] = true
+        return &http.Response{
+            Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+            StatusCode:    i.Status,
+            Proto:         "HTTP/1.1",
+            ProtoMajor:    1,
+            ProtoMinor:    1,
+            Header:        http.Header{"Content-Type": []string{"application/json"}},
+            Body:          io.NopCloser(strings.NewReader(i.Response)),
+            ContentLength: int64(len(i.Response)),
+            Request:       req,
+        }, nil
+    }
+    return nil, fmt.Errorf("no recorded interaction remains for the request %s", request)
+}
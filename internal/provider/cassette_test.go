@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+// the secret BEAM data value of the cassette tests
+const testCassetteSecret = "s3cret-db-pass"
+
+// TestCassetteReplay replays the lifecycle of an app recorded against the simulator.
+func TestCassetteReplay(t *testing.T) {
+	t.Setenv("ACE_REPLAY", filepath.Join("testdata", "cassettes", "app_lifecycle.jsonl"))
+	testCassettePollInterval(t)
+
+	c, err := NewClient(context.Background(), "replay.example.com", "test", "replayed-apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCassetteAppLifecycle(t, c)
+
+	// the requests beyond the cassette fail
+	if _, err := c.StatusBeamInstance(context.Background(), `"webtemplate"`, `"shop"`, `"example.com"`); err == nil {
+		t.Fatal("expected an error for a request not recorded")
+	}
+}
+
+// TestCassetteRecord records the lifecycle of an app against the simulator,
+// verifying the scrubbing of the credentials and the replay of the cassette.
+func TestCassetteRecord(t *testing.T) {
+	const apikey = "recorded-apikey"
+	sim := simulator.New("test", apikey)
+	sim.Start()
+	defer sim.Close()
+	sim.SetDelay(50 * time.Millisecond)
+	testCassettePollInterval(t)
+
+	cassette := filepath.Join(t.TempDir(), "app_lifecycle.jsonl")
+	t.Setenv("ACE_RECORD", cassette)
+	c, err := NewClient(context.Background(), sim.URL(), "test", apikey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCassetteAppLifecycle(t, c)
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{apikey, testCassetteSecret, "Bearer"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("the cassette contains %q", secret)
+		}
+	}
+	for _, match := range cassetteSensitivePattern.FindAllString(string(data), -1) {
+		if !strings.HasSuffix(match, `"`+cassetteRedacted+`"`) {
+			t.Errorf("the cassette contains the credential %s", match)
+		}
+	}
+
+	// the recorded cassette replays without the simulator
+	t.Setenv("ACE_RECORD", "")
+	t.Setenv("ACE_REPLAY", cassette)
+	c, err = NewClient(context.Background(), "replay.example.com", "test", "replayed-apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCassetteAppLifecycle(t, c)
+}
+
+func TestCassetteScrub(t *testing.T) {
+	tests := map[string]struct {
+		credentials []string
+		secrets     []string
+		body        string
+		expected    string
+	}{
+		"credential field": {
+			body:     `{"action":"login","user":"test","secret":"recorded-apikey"}`,
+			expected: `{"action":"login","user":"test","secret":"REDACTED"}`,
+		},
+		"credential": {
+			credentials: []string{"recorded-apikey"},
+			body:        `{"message":"invalid recorded-apikey"}`,
+			expected:    `{"message":"invalid REDACTED"}`,
+		},
+		"short credential": {
+			credentials: []string{"k3y"},
+			body:        `{"message":"invalid k3y"}`,
+			expected:    `{"message":"invalid REDACTED"}`,
+		},
+		"secret": {
+			secrets:  []string{testCassetteSecret},
+			body:     `{"action":"change","data":"node.2.db.PASS:s3cret-db-pass"}`,
+			expected: `{"action":"change","data":"node.2.db.PASS:REDACTED"}`,
+		},
+		"short secret": {
+			secrets:  []string{"pw"},
+			body:     `{"action":"change","data":"node.2.db.PASS:pw"}`,
+			expected: `{"action":"change","data":"node.2.db.PASS:REDACTED"}`,
+		},
+		"overlapping secrets": {
+			secrets:  []string{"pass", testCassetteSecret},
+			body:     `{"action":"change","data":"node.2.db.PASS:s3cret-db-pass,node.3.db.PASS:pass"}`,
+			expected: `{"action":"change","data":"node.2.db.PASS:REDACTED,node.3.db.PASS:REDACTED"}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := withLogSecrets(context.Background(), test.credentials...)
+			ctx = withLogSecretData(ctx, test.secrets...)
+			if got := cassetteScrub(ctx, test.body); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	t.Setenv("ACE_RECORD", "record.jsonl")
+	t.Setenv("ACE_REPLAY", "replay.jsonl")
+	if _, err := NewClient(context.Background(), "replay.example.com", "test", "apikey"); err == nil {
+		t.Fatal("expected an error when both ACE_RECORD and ACE_REPLAY are set")
+	}
+}
+
+// shortens the status polling of the instance transitions
+func testCassettePollInterval(t *testing.T) {
+	interval := statusPollInterval
+	statusPollInterval = 20 * time.Millisecond
+	t.Cleanup(func() { statusPollInterval = interval })
+}
+
+// testCassetteAppLifecycle performs the actions of the creation and deletion of an app.
+func testCassetteAppLifecycle(t *testing.T, c *Client) {
+	t.Helper()
+	ctx := withLogSecretData(context.Background(), testCassetteSecret)
+	r := &appResource{client: c}
+	template, program, domain := `"webtemplate"`, `"shop"`, `"example.com"`
+
+	if _, err := c.CloneBeamModel(ctx, template, program, domain, `"france"`, `"amazonec2"`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ChangeBeamModel(ctx, template, program, domain, `"france"`, `"amazonec2"`, `"node.2.db.PASS:`+testCassetteSecret+`"`); err != nil {
+		t.Fatal(err)
+	}
+	br, err := c.CreateBeamInstance(ctx, template, program, domain, `"4:8:16"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = WaitForStatus(r, ctx, br, template, program, domain, "creating", "created"); err != nil {
+		t.Fatal(err)
+	}
+	if br, err = c.StartBeamInstance(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = WaitForStatus(r, ctx, br, template, program, domain, "starting", "started"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.LockBeamInstance(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if br, err = c.StatusBeamInstance(ctx, template, program, domain); err != nil || br.status != "started" || br.result.lock != "yes" {
+		t.Fatalf("expected a locked started instance, got %v %v", br, err)
+	}
+	if _, err = c.UnLockBeamInstance(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if br, err = c.StopBeamInstance(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = WaitForStatus(r, ctx, br, template, program, domain, "stopping", "created"); err != nil {
+		t.Fatal(err)
+	}
+	if br, err = c.DropBeamInstance(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = WaitForStatus(r, ctx, br, template, program, domain, "deleting", "none"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.DeleteBeamModel(ctx, template, program); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.ExportBeamModel(ctx, template, program); !errors.Is(err, ErrBeamNotFound) {
+		t.Fatalf("expected the model to be deleted, got %v", err)
+	}
+}
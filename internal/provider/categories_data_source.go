@@ -0,0 +1,129 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &categoriesDataSource{}
+    _ datasource.DataSourceWithConfigure = &categoriesDataSource{}
+)
+
+// NewCategoriesDataSource is a helper function to simplify the provider implementation.
+func NewCategoriesDataSource() datasource.DataSource {
+    return &categoriesDataSource{}
+}
+
+// categories Data Source is the data source implementation.
+type categoriesDataSource struct{
+	client *Client
+}
+
+// categoriesDataSourceModel maps the data source schema data.
+type categoriesDataSourceModel struct {
+    Names       []types.String   `tfsdk:"names"`
+    Categories  []categoryModel  `tfsdk:"categories"`
+}
+
+// the provisioning category model
+type categoryModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Title       types.String	`tfsdk:"title"`
+    Regions     []types.String	`tfsdk:"regions"`
+}
+
+func (d *categoriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_categories"
+}
+
+// Schema defines the schema for the data source.
+func (d *categoriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the provisioning categories enabled for the account and the regions offered by each.",
+        Attributes: map[string]schema.Attribute{
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "categories": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "title": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "regions": schema.ListAttribute{
+                            Computed: true,
+                            ElementType: types.StringType,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *categoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state categoriesDataSourceModel
+    tflog.Info(ctx,"AMENESIK:CATEGORIES ENTER:READ");
+
+    categories, err := d.client.ListBeamCategories(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik Provisioning Categories",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.Names = []types.String{}
+    state.Categories = []categoryModel{}
+    for _, c := range categories {
+        item := categoryModel{
+            Name:    types.StringValue(c.Name),
+            Title:   types.StringValue(c.Title),
+            Regions: []types.String{},
+        }
+        for _, r := range c.Regions {
+            item.Regions = append(item.Regions, types.StringValue(r))
+        }
+        state.Names = append(state.Names, item.Name)
+        state.Categories = append(state.Categories, item)
+    }
+
+    diags := resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:CATEGORIES LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *categoriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// the categories of the account of the provisioning data source tests
+const testCategories = `{"status":"200","result":[
+	{"name":"amazonec2","title":"Amazon EC2","regions":["france","ireland"]},
+	{"name":"windowsazure","title":"Microsoft Azure","regions":["france","germany"]},
+	{"name":"openstack","title":"OpenStack"}]}`
+
+// testCategoriesClient returns a client of a server listing the categories.
+func testCategoriesClient(t *testing.T, status int, body string) *Client {
+	return testClient(t, func(req map[string]string) (int, string) {
+		if req["action"] != "list" || req["subject"] != "category" {
+			return http.StatusBadRequest, "unexpected request"
+		}
+		return status, body
+	})
+}
+
+// testStrings returns the values of the strings.
+func testStrings(values []types.String) []string {
+	result := []string{}
+	for _, v := range values {
+		result = append(result, v.ValueString())
+	}
+	return result
+}
+
+func TestCategoriesDataSource(t *testing.T) {
+	resp := testDataSourceRead(t, NewCategoriesDataSource(), testCategoriesClient(t, http.StatusOK, testCategories), nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state categoriesDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := testStrings(state.Names); !reflect.DeepEqual(got, []string{"amazonec2", "windowsazure", "openstack"}) {
+		t.Errorf("unexpected names %v", got)
+	}
+	if len(state.Categories) != 3 {
+		t.Fatalf("expected 3 categories, got %d", len(state.Categories))
+	}
+	if c := state.Categories[1]; c.Title.ValueString() != "Microsoft Azure" || !reflect.DeepEqual(testStrings(c.Regions), []string{"france", "germany"}) {
+		t.Errorf("unexpected category %v", c)
+	}
+	if c := state.Categories[2]; c.Regions == nil || len(c.Regions) != 0 {
+		t.Errorf("expected an empty list of regions, got %v", c.Regions)
+	}
+}
+
+func TestCategoriesDataSourceFailure(t *testing.T) {
+	for name, body := range map[string]string{
+		"status":   `{"status":"500","message":"failure"}`,
+		"response": `not json`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := testDataSourceRead(t, NewCategoriesDataSource(), testCategoriesClient(t, http.StatusOK, body), nil)
+			if got := testDataSourceError(resp); got != "Unable to List Amenesik Provisioning Categories" {
+				t.Errorf("unexpected error %q", got)
+			}
+		})
+	}
+}
@@ -19,11 +19,15 @@ package provider
 import(
   "context"
   "encoding/json"
+  "errors"
   "fmt"
+  "strconv"
   "strings"
+  "time"
   "net/http"
   "bytes"
   "io/ioutil"
+  "sync"
   "github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -31,11 +35,15 @@ import(
 // The ACE / BEAM CLIENT 
 // ---------------------
 type Client struct {
+    mu         sync.Mutex
     httpClient *http.Client
     baseURL string
     account string
     apikey  string
     token   string
+    session BeamToken
+    cache   *tokenCache
+    cached  bool
 }
 
 // -------------------------
@@ -54,8 +62,11 @@ type BeamToken struct {
 // The ACE / BEAM / APP STATE
 // --------------------------
 type BeamInstanceState struct {
-    name   string
-    status string
+    name     string
+    status   string
+    lock     string
+    category string
+    region   string
 }
 
 // ------------------------------
@@ -66,69 +77,495 @@ type BeamResponse struct {
     result BeamInstanceState
 }
 
+// ------------------------------------
+// A BEAM template of the ACE catalogue
+// ------------------------------------
+type BeamTemplate struct {
+    Name string            `json:"name"`
+    Tags map[string]string `json:"tags"`
+}
+
+// -------------------------------------
+// A node of a running BEAM instance
+// -------------------------------------
+type BeamInstanceNode struct {
+    Name      string `json:"name"`
+    Type      string `json:"type"`
+    Base      string `json:"base"`
+    Hostname  string `json:"hostname"`
+    PublicIp  string `json:"public_ip"`
+    PrivateIp string `json:"private_ip"`
+    Provider  string `json:"provider"`
+    Region    string `json:"region"`
+    Status    string `json:"status"`
+}
+
+// -------------------------------------
+// An ACE provisioning category
+// -------------------------------------
+type BeamCategory struct {
+    Name    string   `json:"name"`
+    Title   string   `json:"title"`
+    Regions []string `json:"regions"`
+}
+
+// -------------------------------------
+// An ACE account probe definition
+// -------------------------------------
+type BeamProbeDefinition struct {
+    Name      string `json:"name"`
+    Metric    string `json:"metric"`
+    Condition string `json:"condition"`
+    Threshold string `json:"threshold"`
+    Type      string `json:"type"`
+    Nature    string `json:"nature"`
+    Behaviour string `json:"behaviour"`
+}
+
+// -------------------------------------
+// An ACE account monitoring metric
+// -------------------------------------
+type BeamMetric struct {
+    Name        string `json:"name"`
+    Description string `json:"description"`
+    Frequency   string `json:"frequency"`
+    Collector   string `json:"collector"`
+}
+
+// -------------------------------------
+// An ACE node type capability
+// -------------------------------------
+type BeamTypeCapability struct {
+    Name       string   `json:"name"`
+    Properties []string `json:"properties"`
+}
+
+// -------------------------------------
+// An ACE importable TOSCA node type
+// -------------------------------------
+type BeamNodeTypeDefinition struct {
+    Name         string               `json:"name"`
+    Create       string               `json:"create"`
+    Start        string               `json:"start"`
+    Stop         string               `json:"stop"`
+    Save         string               `json:"save"`
+    Delete       string               `json:"delete"`
+    TcpPorts     []string             `json:"tcp_port"`
+    UdpPorts     []string             `json:"udp_port"`
+    Capabilities []BeamTypeCapability `json:"capabilities"`
+}
+
 // --------------------------------------
 // remove the quotes from around a string
 // --------------------------------------
 func UnQuote( v string ) string {
-	if v[0:1] != "\"" {
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
 	    return v
 	}
-	nn := v[1:]
-	return nn[:len(nn)-1]
+	return v[1:len(v)-1]
 }
 
-// parse a simple JSON formated string to find a named value
+// parse a JSON formated object string to find a named scalar value,
+// returning "none" when the value is absent
 func BeamJsonParser(js string, jn string) string {
-    one := js[1:]
-    two := one[:len(one)-1]
-    parts := strings.Split(two,",")
-    items := len(parts)
-    for items > 0 {
-	items--;
-	three := strings.Split(parts[items],":")
-	nnn := three[0]
-	nn := nnn[1:]
-	name := nn[:len(nn)-1]
-	if name == jn {
-	    nnn := three[1]
-	    nn := nnn[1:]
-	    return nn[:len(nn)-1]
-	}
+    return beamField(beamFields([]byte(js)), jn)
+}
+
+// returns the named value of the scalar values of a response, "none" when absent
+func beamField(fields map[string]string, name string) string {
+    if v, ok := fields[name]; ok {
+        return v
     }
     return "none"
 }
 
+// returns true when the lock value of an instance status reports a locked
+// instance, an absent or unknown lock value reporting an unlocked instance
+func isLocked(lock string) bool {
+    switch strings.ToLower(lock) {
+    case "yes", "locked", "true", "1":
+        return true
+    }
+    return false
+}
+
+// returns the scalar values of a JSON response object by name, such as
+// the login token expiry whose date and time values defeat the simple
+// parser, any values that are not scalars being ignored.
+func beamFields(body []byte) map[string]string {
+    fields := map[string]string{}
+    var values map[string]interface{}
+    if err := json.Unmarshal(body, &values); err != nil {
+        return fields
+    }
+    for k, v := range values {
+        switch t := v.(type) {
+        case string:
+            fields[k] = t
+        case float64:
+            fields[k] = strconv.FormatFloat(t, 'f', -1, 64)
+        case bool:
+            fields[k] = strconv.FormatBool(t)
+        }
+    }
+    return fields
+}
+
+// returns the ACE API endpoint of a host name or of an explicit URL,
+// such as that of a local simulator
+func apiURL(host string) string {
+    if strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://") {
+        return strings.TrimSuffix(host, "/")+"/aec/api.php"
+    }
+    return "https://"+host+"/aec/api.php"
+}
+
 // ---------------------------------
 // Creation of a new ACE/BEAM CLIENT
 // ---------------------------------
+// The login of the account is deferred
+// until the first API request, allowing
+// configurations to be validated and
+// planned without network access.
+// ---------------------------------
 func NewClient(ctx context.Context,baseURL string, account string, apikey string) (*Client, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: NEW CLIENT: "+baseURL)
-    reqBody := map[string]string{"action": "login", "user": account, "secret": apikey }
-    body, _ := json.Marshal(reqBody)
-    url := "https://"+baseURL+"/aec/api.php"
-    req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
-    resp, err := http.DefaultClient.Do(req)
+    ctx = clientLogContext(ctx, apikey)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: NEW CLIENT: "+baseURL)
+    httpClient, err := newHTTPClient(ctx)
+    if err != nil {
+        return nil, err
+    }
+    return &Client{
+        httpClient: httpClient,
+        baseURL:    apiURL(baseURL),
+	account:    account,
+	apikey:     apikey,
+    }, nil
+}
+
+// ---------------------------------------------
+// Creation of a new ACE/BEAM CLIENT for a token
+// ---------------------------------------------
+// The pre-issued token, such as one issued by
+// an SSO gateway, replaces the login action and
+// is validated once by a whoami action before
+// the first API request.
+// ---------------------------------------------
+func NewClientWithToken(ctx context.Context,baseURL string, account string, token string) (*Client, error) {
+    ctx = clientLogContext(ctx, token)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: NEW CLIENT: TOKEN: "+baseURL)
+    httpClient, err := newHTTPClient(ctx)
     if err != nil {
         return nil, err
     }
+    return &Client{
+        httpClient: httpClient,
+        baseURL:    apiURL(baseURL),
+	account:    account,
+	token:      token,
+    }, nil
+}
+
+// returned when the authentication token has expired or was rejected
+var ErrBeamTokenExpired = errors.New("the authentication token has expired, a new token must be provided")
+
+// the formats of the token expiry returned by ACE
+var beamExpiryFormats = []string{
+    time.RFC3339Nano,
+    time.RFC3339,
+    "2006-01-02T15:04:05",
+    "2006-01-02 15:04:05",
+    "2006-01-02 15:04",
+    time.RFC1123Z,
+    time.RFC1123,
+    time.RFC850,
+    "2006-01-02",
+}
+
+// ----------------------------------------------------------------------
+// PARSE BEAM EXPIRY ( expires )
+// ----------------------------------------------------------------------
+// Returns the time of a token expiry, which may be a date and time in
+// any of the supported formats or a Unix time in seconds or milliseconds.
+// ----------------------------------------------------------------------
+func ParseBeamExpiry(expires string) (time.Time, bool) {
+    v := strings.TrimSpace(expires)
+    if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+        if n > 100000000000 {
+            return time.UnixMilli(n), true
+        }
+        return time.Unix(n, 0), true
+    }
+    for _, layout := range beamExpiryFormats {
+        if t, err := time.Parse(layout, v); err == nil {
+            return t, true
+        }
+    }
+    return time.Time{}, false
+}
+
+// ----------------------------------------------------------------------
+// AUTHENTICATE ( )
+// ----------------------------------------------------------------------
+// Returns the session of the client, performing the login of the account
+// or the validation of the pre-issued token on the first call only. The
+// session is then shared by all the resources of the provider instance.
+// A failed authentication is not retained and is retried by later calls.
+// ----------------------------------------------------------------------
+func (c *Client) authenticate(ctx context.Context) (BeamToken, error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if c.session.auth != "" {
+        return c.session, nil
+    }
+
+    var session BeamToken
+    var err error
+    if c.token != "" {
+        session, err = c.whoAmI(ctx, c.token)
+    } else if c.cache != nil {
+        session, err = c.cachedLogin(ctx)
+    } else {
+        session, err = c.login(ctx)
+    }
+    if err != nil {
+        return BeamToken{}, err
+    }
+    c.session = session
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: AUTHENTICATED: "+session.account)
+    return session, nil
+}
+
+// performs the login of the account failing when no token is returned
+func (c *Client) login(ctx context.Context) (BeamToken, error) {
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LOGIN: "+c.account)
+    session, err := c.beamLogin(ctx)
+    if err == nil && (session.auth == "" || session.auth == "none") {
+        err = fmt.Errorf("failed to login: %s", session.status)
+    }
+    return session, err
+}
+
+// ----------------------------------------------------------------------
+// CACHED LOGIN ( )
+// ----------------------------------------------------------------------
+// Returns the session token of the token cache while it remains valid,
+// otherwise performs the login of the account and stores the token. The
+// cache is locked meanwhile so that concurrent provider processes reuse
+// the token of a single login. The cache failures are only logged.
+// ----------------------------------------------------------------------
+func (c *Client) cachedLogin(ctx context.Context) (BeamToken, error) {
+    unlock, err := c.cache.lock(ctx)
+    if err != nil {
+        tflog.SubsystemWarn(ctx, clientLogSubsystem, "AMENESIK:ACE: TOKEN CACHE: LOCK: "+err.Error())
+        return c.login(ctx)
+    }
+    defer unlock()
+    if session, ok := c.cache.load(); ok {
+        tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: TOKEN CACHE: REUSED: "+c.account)
+        c.cached = true
+        return session, nil
+    }
+    session, err := c.login(ctx)
+    if err != nil {
+        return session, err
+    }
+    if err := c.cache.store(session); err != nil {
+        tflog.SubsystemWarn(ctx, clientLogSubsystem, "AMENESIK:ACE: TOKEN CACHE: STORE: "+err.Error())
+    }
+    return session, nil
+}
+
+// ----------------------------------------------------------------------
+// REJECTED ( session )
+// ----------------------------------------------------------------------
+// Discards the session when its token, reused from the token cache, was
+// rejected by ACE, such as when revoked before its expiry, returning
+// true when the request may be retried with the token of a new login.
+// ----------------------------------------------------------------------
+func (c *Client) rejected(session BeamToken) bool {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    if !c.cached {
+        return false
+    }
+    if c.session.auth == session.auth {
+        c.cache.remove()
+        c.session = BeamToken{}
+        c.cached = false
+    }
+    return true
+}
+
+// ----------------------------------------------------------------------
+// USE TOKEN CACHE ( dir )
+// ----------------------------------------------------------------------
+// Enables the persistent cache of the login token of the client account,
+// stored in the directory and shared with other provider processes.
+// ----------------------------------------------------------------------
+func (c *Client) UseTokenCache(dir string) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.cache = newTokenCache(dir, c.baseURL, c.account, c.apikey)
+}
+
+// ----------------------------------------------------------------------
+// SESSION ( )
+// ----------------------------------------------------------------------
+// Returns the authenticated account, user, role and token expiry of the
+// client, authenticating the client when not yet done.
+// ----------------------------------------------------------------------
+func (c *Client) Session(ctx context.Context) (BeamToken, error) {
+    ctx = c.logContext(ctx)
+    return c.authenticate(ctx)
+}
+
+// ----------------------------------------------------------------------
+// WHO AM I ( )
+// ----------------------------------------------------------------------
+// Returns the account, user, role and expiry of the client token,
+// failing with ErrBeamTokenExpired when the token has expired or is
+// rejected by ACE.
+// ----------------------------------------------------------------------
+func (c *Client) WhoAmI(ctx context.Context) (BeamToken, error) {
+    ctx = c.logContext(ctx)
+    session, err := c.authenticate(ctx)
+    if err != nil {
+        return session, err
+    }
+    return c.whoAmI(ctx, session.auth)
+}
+
+// performs the whoami action of the token
+func (c *Client) whoAmI(ctx context.Context, token string) (BeamToken, error) {
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: WHO AM I: "+c.account)
+    reqBody := map[string]string{"action": "whoami", "account": c.account }
+    var result json.RawMessage
+    bodyBytes, err := c.post(ctx, token, reqBody, "failed to validate token")
+    if err == nil {
+        err = beamResult(bodyBytes, &result)
+    }
+    if err != nil {
+        // the token is rejected as unauthorized or forbidden
+        if code := httpStatusCode(err); code == http.StatusUnauthorized || code == http.StatusForbidden {
+            return BeamToken{}, fmt.Errorf("%w: %s", ErrBeamTokenExpired, err.Error())
+        }
+        return BeamToken{}, err
+    }
+    if len(result) == 0 {
+        result = bodyBytes
+    }
+    fields := beamFields(result)
+    session := BeamToken{
+        status:  "200",
+        auth:    token,
+        account: fields["account"],
+        user:    fields["user"],
+        role:    fields["role"],
+        expires: fields["expires"],
+    }
+    if session.account == "" {
+        session.account = c.account
+    }
+    if expiry, ok := ParseBeamExpiry(session.expires); ok && !expiry.After(time.Now()) {
+        return session, fmt.Errorf("%w (expired at %s)", ErrBeamTokenExpired, session.expires)
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: WHO AM I: "+session.account+": SUCCESS")
+    return session, nil
+}
+
+// ----------------------------------------------------------------------
+// BEAM LOGIN ( )
+// ----------------------------------------------------------------------
+// Performs the login action of the account returning the session token
+// together with the authenticated account, user, role and token expiry.
+// ----------------------------------------------------------------------
+func (c *Client) beamLogin(ctx context.Context) (BeamToken, error) {
+    reqBody := map[string]string{"action": "login", "user": c.account, "secret": c.apikey }
+    body, _ := json.Marshal(reqBody)
+    req, _ := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewReader(body))
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return BeamToken{}, err
+    }
     defer resp.Body.Close()
 
     bodyBytes, err := ioutil.ReadAll(resp.Body)
     if err != nil {
-        return nil, err
+        return BeamToken{}, err
+    }
+
+    fields := beamFields(bodyBytes)
+    session := BeamToken{
+        status:  fields["status"],
+        auth:    BeamJsonParser( string(bodyBytes), "auth" ),
+        account: fields["account"],
+        user:    fields["user"],
+        role:    fields["role"],
+        expires: fields["expires"],
+    }
+    if session.account == "" {
+        session.account = c.account
     }
+    return session, nil
+}
 
-    auth := BeamJsonParser( string(bodyBytes), "auth" )
+// ----------------------------------------------------------------------
+// NEW SESSION ( )
+// ----------------------------------------------------------------------
+// Performs a separate login of the client account, returning a session
+// token distinct from the client token for use by external tools.
+// ----------------------------------------------------------------------
+func (c *Client) NewSession(ctx context.Context) (BeamToken, error) {
+    ctx = c.logContext(ctx)
+    if c.apikey == "" {
+        return BeamToken{}, errors.New("a new session requires the API KEY of the account, the provider being configured with a token")
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: NEW SESSION: "+c.account)
+    session, err := c.beamLogin(ctx)
+    if err != nil {
+        return session, err
+    }
+    if session.auth == "" || session.auth == "none" {
+        return session, fmt.Errorf("failed to login: %s", session.status)
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: NEW SESSION: "+c.account+": SUCCESS")
+    return session, nil
+}
 
-    tflog.Info(ctx,"AMENESIK:ACE: NEW CLIENT: SUCCESS: AUTH: "+baseURL)
+// ----------------------------------------------------------------------
+// END SESSION ( token )
+// ----------------------------------------------------------------------
+// Revokes a session token previously returned by NewSession.
+// ----------------------------------------------------------------------
+func (c *Client) EndSession(ctx context.Context, token string) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: END SESSION: "+c.account)
+    reqBody := map[string]string{"action": "logout", "account": c.account }
+    bodyBytes, err := c.post(ctx, token, reqBody, "failed to logout")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: END SESSION: "+c.account+": SUCCESS")
+    return nil
+}
 
-    return &Client{
-        httpClient: &http.Client{},
-        baseURL:    url,
-	account:    account,
-	apikey:     apikey,
-	token:      auth,
-    }, nil
+// ----------------------------------------------------------------------
+// BEAM MODEL NAME ( template, program )
+// ----------------------------------------------------------------------
+// Returns the name of the BEAM model cloned from the template using the
+// program, which replaces the terminal "template" of the template name.
+// The name ACE assigns to models cloned from templates not ending in
+// "template" cannot be derived, and is only known once cloned.
+// ----------------------------------------------------------------------
+func BeamModelName(template string, program string) (string, bool) {
+    if !strings.HasSuffix(template, "template") {
+        return "", false
+    }
+    return strings.TrimSuffix(template, "template") + program, true
 }
 
 // ----------------------------------------------------------------------
@@ -140,27 +577,25 @@ func NewClient(ctx context.Context,baseURL string, account string, apikey string
 // Cloud Provider will be set to the value of "category" in the "region".
 // ----------------------------------------------------------------------
 func (c *Client) CloneBeamModel(ctx context.Context,template string, program string, domain string, region string, category string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "clone", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "region": UnQuote(region), "provider": UnQuote(category) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program)
+    reqBody := map[string]string{"action": "clone", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "region": UnQuote(region), "provider": UnQuote(category) }
+    body, err := c.beamRequest(ctx, reqBody, "failed to create model")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to create model: %s", resp.Status)
-    }
 
+    // the derived name, being that planned, is preferred to the name
+    // assigned by ACE, which is only used when it cannot be derived
     var bi BeamResponse
     bi.status = "cloned"
-    bi.result.name = program
+    name, ok := BeamModelName(UnQuote(template), UnQuote(program))
+    if !ok {
+        name = beamFields(body)["id"]
+    }
+    bi.result.name = name
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -173,27 +608,18 @@ func (c *Client) CloneBeamModel(ctx context.Context,template string, program str
 // Cloud Provider will be set to the value of "category" in the "region".
 // ----------------------------------------------------------------------
 func (c *Client) ChangeBeamModel(ctx context.Context,template string, program string, domain string, region string, category string, data string ) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "change", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "region": UnQuote(region), "provider": UnQuote(category), "data":UnQuote(data) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program)
+    reqBody := map[string]string{"action": "change", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "region": UnQuote(region), "provider": UnQuote(category), "data":UnQuote(data) }
+    if _, err := c.beamRequest(ctx, reqBody, "failed to create model"); err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to create model: %s", resp.Status)
-    }
 
     var bi BeamResponse
     bi.status = "cloned"
     bi.result.name = program
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CLONE BEAM MODEL: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -205,34 +631,21 @@ func (c *Client) ChangeBeamModel(ctx context.Context,template string, program st
 // and param information as required by the actual BEAM model.
 // ----------------------------------------------------------------------
 func (c *Client) CreateBeamInstance(ctx context.Context,template string, program string, domain string, param string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: CREATE BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "create", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "param": UnQuote(param) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to create instance: %s", resp.Status)
-    }
-
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "create", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain), "param": UnQuote(param) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to create instance")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
 
-    tflog.Info(ctx,"AMENESIK:ACE: CREATE BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -243,33 +656,20 @@ func (c *Client) CreateBeamInstance(ctx context.Context,template string, program
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
 func (c *Client) StartBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: START BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "start", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to start instance: %s", resp.Status)
-    }
-
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: START BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "start", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to start instance")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: START BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: START BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -280,33 +680,20 @@ func (c *Client) StartBeamInstance(ctx context.Context,template string, program
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
 func (c *Client) LockBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: LOCK BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "lock", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LOCK BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "lock", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to start instance")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to start instance: %s", resp.Status)
-    }
-
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
-    if err != nil {
-        return nil, err
-    }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: LOCK BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LOCK BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -317,33 +704,20 @@ func (c *Client) LockBeamInstance(ctx context.Context,template string, program s
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
 func (c *Client) UnLockBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: UNLOCK BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "unlock", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
-    if err != nil {
-        return nil, err
-    }
-    defer resp.Body.Close()
-
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to start instance: %s", resp.Status)
-    }
-
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UNLOCK BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "unlock", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to start instance")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: UNLOCK BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UNLOCK BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
@@ -354,211 +728,583 @@ func (c *Client) UnLockBeamInstance(ctx context.Context,template string, program
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
 func (c *Client) StatusBeamInstance(ctx context.Context,template string, program string, domain string,) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "status", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "status", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to retrieve status of instance")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to retrieve status of instance: %s", resp.Status)
-    }
+    fields := beamFields(bodyBytes)
+    var bi BeamResponse
+    bi.status = beamField(fields, "status")
+    bi.result.name = beamField(fields, "id")
+    bi.result.status = "200"
+    bi.result.lock = beamField(fields, "lock")
+    bi.result.category = beamField(fields, "provider")
+    bi.result.region = beamField(fields, "region")
+    return &bi, nil
+}
 
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+// ----------------------------------------------------------------------
+// STOP BEAM INSTANCE ( template, program )
+// ----------------------------------------------------------------------
+// Stops the BEAM Application Controller instance as described by the 
+// cloned template described by the template and program parameters.
+// ----------------------------------------------------------------------
+func (c *Client) StopBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: STOP BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "stop", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to stop instance")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
-    json.NewDecoder(resp.Body).Decode(&bi)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: STOP BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
 // ----------------------------------------------------------------------
-// STOP BEAM INSTANCE ( template, program )
+// SUSPEND BEAM INSTANCE ( template, program )
 // ----------------------------------------------------------------------
-// Stops the BEAM Application Controller instance as described by the 
+// Suspends the BEAM Application Controller instance as described by the 
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
-func (c *Client) StopBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: STOP BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "stop", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+func (c *Client) SuspendBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: SUSPEND BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "suspend", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to suspend instance")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to stop instance: %s", resp.Status)
-    }
+    var bi BeamResponse
+    bi.status = BeamJsonParser(string(bodyBytes),"status")
+    bi.result.name = BeamJsonParser(string(bodyBytes),"id")
+    bi.result.status = "200"
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: SUSPEND BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    return &bi, nil
+}
 
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+// ----------------------------------------------------------------------
+// RESUME BEAM INSTANCE ( template, program )
+// ----------------------------------------------------------------------
+// Resumes the BEAM Application Controller instance as described by the 
+// cloned template described by the template and program parameters.
+// ----------------------------------------------------------------------
+func (c *Client) ResumeBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: RESUME BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "resume", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to resume instance")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
     var bi BeamResponse
     bi.status = BeamJsonParser(string(bodyBytes),"status")
     bi.result.name = BeamJsonParser(string(bodyBytes),"id")
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: STOP BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: RESUME BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
 // ----------------------------------------------------------------------
-// SUSPEND BEAM INSTANCE ( template, program )
+// DROP BEAM INSTANCE ( template, program )
 // ----------------------------------------------------------------------
-// Suspends the BEAM Application Controller instance as described by the 
+// Deletes the BEAM Application Controller instance as described by the 
 // cloned template described by the template and program parameters.
 // ----------------------------------------------------------------------
-func (c *Client) SuspendBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: SUSPEND BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "suspend", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
-
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+func (c *Client) DropBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DROP BEAM INSTANCE: "+template+"-"+program)
+    reqBody := map[string]string{"action": "drop", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to delete instance")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
+    tflog.SubsystemDebug(ctx, clientLogSubsystem, "AMENESIK:ACE: STATUS: "+string(bodyBytes))
 
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to suspend instance: %s", resp.Status)
-    }
+    var bi BeamResponse
+    bi.status = BeamJsonParser(string(bodyBytes),"status")
+    bi.result.name = BeamJsonParser(string(bodyBytes),"id")
+    bi.result.status = "200"
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DROP BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    return &bi, nil
+}
 
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
-    if err != nil {
+// ----------------------------------------------------------------------
+// DELETE BEAM MODEL ( template, program )
+// ----------------------------------------------------------------------
+// Deletes the BEAM model described by template and program parameters.
+// ----------------------------------------------------------------------
+func (c *Client) DeleteBeamModel(ctx context.Context,template string, program string) (*BeamResponse, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM MODEL: "+template+"-"+program)
+    reqBody := map[string]string{"action": "delete", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    if _, err := c.beamRequest(ctx, reqBody, "failed to delete model instance"); err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
 
     var bi BeamResponse
-    bi.status = BeamJsonParser(string(bodyBytes),"status")
-    bi.result.name = BeamJsonParser(string(bodyBytes),"id")
+    bi.status = "deleted"
+    bi.result.name = program
     bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: SUSPEND BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM MODEL: "+template+"-"+program+": SUCCESS")
     return &bi, nil
 }
 
 // ----------------------------------------------------------------------
-// RESUME BEAM INSTANCE ( template, program )
+// BEAM REQUEST ( request )
 // ----------------------------------------------------------------------
-// Resumes the BEAM Application Controller instance as described by the 
-// cloned template described by the template and program parameters.
+// Posts the action request to the ACE API, authenticated by the client
+// session, returning the body of the response, or an error described by
+// failure when the response status is not 200.
 // ----------------------------------------------------------------------
-func (c *Client) ResumeBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: RESUME BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "resume", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
+func (c *Client) beamRequest(ctx context.Context, reqBody map[string]string, failure string) ([]byte, error) {
+    session, err := c.authenticate(ctx)
+    if err != nil {
+        return nil, err
+    }
+    reqBody["account"] = c.account
+    if c.account == "" {
+        reqBody["account"] = session.account
+    }
+    bodyBytes, err := c.post(ctx, session.auth, reqBody, failure)
+    if httpStatusCode(err) == http.StatusUnauthorized && c.rejected(session) {
+        tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: TOKEN CACHE: REJECTED: "+c.account)
+        if session, err = c.authenticate(ctx); err != nil {
+            return nil, err
+        }
+        bodyBytes, err = c.post(ctx, session.auth, reqBody, failure)
+    }
+    return bodyBytes, err
+}
+
+// posts the request authenticated by the token returning the response body
+func (c *Client) post(ctx context.Context, token string, reqBody map[string]string, failure string) ([]byte, error) {
+    reqBody["auth"] = token
     body, _ := json.Marshal(reqBody)
 
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+    req, _ := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewReader(body))
+    req.Header.Set("Authorization", "Bearer "+token)
+    resp, err := c.httpClient.Do(req)
     if err != nil {
         return nil, err
     }
     defer resp.Body.Close()
 
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, fmt.Errorf("%s: %w", failure, ErrBeamNotFound)
+    }
     if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to resume instance: %s", resp.Status)
+        return nil, &HTTPStatusError{Code: resp.StatusCode, Message: failure+": "+resp.Status}
     }
 
     bodyBytes, err := ioutil.ReadAll(resp.Body)
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
+    return bodyBytes, nil
+}
 
-    var bi BeamResponse
-    bi.status = BeamJsonParser(string(bodyBytes),"status")
-    bi.result.name = BeamJsonParser(string(bodyBytes),"id")
-    bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: RESUME BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
-    return &bi, nil
+
+// returned by API requests addressing an account object that does not exist
+var ErrBeamNotFound = errors.New("not found")
+
+// -----------------------------------------
+// An ACE API response with an error status,
+// being the HTTP status of the response or
+// the numeric status member of its body
+// -----------------------------------------
+type HTTPStatusError struct {
+    Code    int
+    Message string
+}
+
+func (e *HTTPStatusError) Error() string {
+    return e.Message
+}
+
+// returns the status code of an HTTPStatusError, or zero for other errors
+func httpStatusCode(err error) int {
+    var status *HTTPStatusError
+    if errors.As(err, &status) {
+        return status.Code
+    }
+    return 0
 }
 
 // ----------------------------------------------------------------------
-// DROP BEAM INSTANCE ( template, program )
+// BEAM RESULT ( body, result )
 // ----------------------------------------------------------------------
-// Deletes the BEAM Application Controller instance as described by the 
-// cloned template described by the template and program parameters.
+// Decodes the result member of a JSON formatted ACE API response body,
+// failing when the status member reports an error.
 // ----------------------------------------------------------------------
-func (c *Client) DropBeamInstance(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: DROP BEAM INSTANCE: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "drop", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
+func beamResult(body []byte, result interface{}) error {
+    var response struct {
+        Status  string          `json:"status"`
+        Message string          `json:"message"`
+        Result  json.RawMessage `json:"result"`
+    }
+    if err := json.Unmarshal(body, &response); err != nil {
+        return fmt.Errorf("invalid response: %s", err.Error())
+    }
+    if response.Status == "404" || response.Status == "none" {
+        return ErrBeamNotFound
+    }
+    if response.Status != "" && response.Status != "200" && response.Status != "ok" {
+        message := fmt.Sprintf("failed with status %s: %s", response.Status, response.Message)
+        if code, err := strconv.Atoi(response.Status); err == nil {
+            return &HTTPStatusError{Code: code, Message: message}
+        }
+        return errors.New(message)
+    }
+    if len(response.Result) == 0 || result == nil {
+        return nil
+    }
+    return json.Unmarshal(response.Result, result)
+}
 
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+// ----------------------------------------------------------------------
+// LIST BEAM TEMPLATES ( )
+// ----------------------------------------------------------------------
+// Lists the BEAM templates of the catalogue visible to the account with
+// their tags, such as Title, Author, Version and Date.
+// ----------------------------------------------------------------------
+func (c *Client) ListBeamTemplates(ctx context.Context) ([]BeamTemplate, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM TEMPLATES: "+c.account)
+    reqBody := map[string]string{"action": "list", "subject": "beam" }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list templates")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
 
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to delete instance: %s", resp.Status)
+    var templates []BeamTemplate
+    if err := beamResult(bodyBytes, &templates); err != nil {
+        return nil, err
     }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM TEMPLATES: "+c.account+": SUCCESS")
+    return templates, nil
+}
 
-    bodyBytes, err := ioutil.ReadAll(resp.Body)
+// ----------------------------------------------------------------------
+// LIST BEAM INSTANCE NODES ( template, program, domain )
+// ----------------------------------------------------------------------
+// Lists the hardware and software nodes of the BEAM Application
+// Controller instance described by the template and program parameters
+// with their hostnames, addresses, provisioning and status information.
+// ----------------------------------------------------------------------
+func (c *Client) ListBeamInstanceNodes(ctx context.Context,template string, program string, domain string) ([]BeamInstanceNode, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM INSTANCE NODES: "+template+"-"+program)
+    reqBody := map[string]string{"action": "nodes", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program), "domain": UnQuote(domain) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list instance nodes")
     if err != nil {
         return nil, err
     }
-    tflog.Info(ctx,"AMENESIK:ACE: STATUS: "+string(bodyBytes));
 
-    var bi BeamResponse
-    bi.status = BeamJsonParser(string(bodyBytes),"status")
-    bi.result.name = BeamJsonParser(string(bodyBytes),"id")
-    bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: DROP BEAM INSTANCE: "+template+"-"+program+": SUCCESS")
-    return &bi, nil
+    var nodes []BeamInstanceNode
+    if err := beamResult(bodyBytes, &nodes); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM INSTANCE NODES: "+template+"-"+program+": SUCCESS")
+    return nodes, nil
 }
 
 // ----------------------------------------------------------------------
-// DELETE BEAM MODEL ( template, program )
+// LIST BEAM CATEGORIES ( )
 // ----------------------------------------------------------------------
-// Deletes the BEAM model described by template and program parameters.
+// Lists the provisioning categories enabled for the account, such as
+// amazonec2 or windowsazure, with the regions offered by each of them.
 // ----------------------------------------------------------------------
-func (c *Client) DeleteBeamModel(ctx context.Context,template string, program string) (*BeamResponse, error) {
-    tflog.Info(ctx,"AMENESIK:ACE: DELETE BEAM MODEL: "+template+"-"+program)
-    reqBody := map[string]string{"auth":c.token,"action": "delete", "subject": "beam", "account": c.account, "template": UnQuote(template), "program": UnQuote(program) }
-    body, _ := json.Marshal(reqBody)
+func (c *Client) ListBeamCategories(ctx context.Context) ([]BeamCategory, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM CATEGORIES: "+c.account)
+    reqBody := map[string]string{"action": "list", "subject": "category" }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list categories")
+    if err != nil {
+        return nil, err
+    }
+
+    var categories []BeamCategory
+    if err := beamResult(bodyBytes, &categories); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM CATEGORIES: "+c.account+": SUCCESS")
+    return categories, nil
+}
+
+// ----------------------------------------------------------------------
+// EXPORT BEAM MODEL ( TEMPLATE, PROGRAM )
+// ----------------------------------------------------------------------
+// Returns the TOSCA YAML document of an existing BEAM model, such as a
+// model owned by another team, for local inspection.
+// ----------------------------------------------------------------------
+func (c *Client) ExportBeamModel(ctx context.Context,template string, program string) (string, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: EXPORT BEAM MODEL: "+template+"-"+program)
+    reqBody := map[string]string{"action": "export", "subject": "beam", "template": UnQuote(template), "program": UnQuote(program) }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to export model")
+    if err != nil {
+        return "", err
+    }
+
+    var document string
+    if err := beamResult(bodyBytes, &document); err != nil {
+        return "", err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: EXPORT BEAM MODEL: "+template+"-"+program+": SUCCESS")
+    return document, nil
+}
 
-    req, _ := http.NewRequest("POST", c.baseURL, bytes.NewReader(body))
-    req.Header.Set("Authorization", "Bearer "+c.token)
-    resp, err := http.DefaultClient.Do(req)
+// ----------------------------------------------------------------------
+// LIST BEAM PROBES ( )
+// ----------------------------------------------------------------------
+// Lists the monitoring probe definitions of the account, which may be
+// named by the Probe tags and node probe requirements of BEAM documents.
+// ----------------------------------------------------------------------
+func (c *Client) ListBeamProbes(ctx context.Context) ([]BeamProbeDefinition, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM PROBES: "+c.account)
+    reqBody := map[string]string{"action": "list", "subject": "probe" }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list probes")
     if err != nil {
         return nil, err
     }
-    defer resp.Body.Close()
 
-    if resp.StatusCode != 200 {
-        return nil, fmt.Errorf("failed to delete model instance: %s", resp.Status)
+    var probes []BeamProbeDefinition
+    if err := beamResult(bodyBytes, &probes); err != nil {
+        return nil, err
     }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM PROBES: "+c.account+": SUCCESS")
+    return probes, nil
+}
 
-    var bi BeamResponse
-    bi.status = "deleted"
-    bi.result.name = program
-    bi.result.status = "200"
-    tflog.Info(ctx,"AMENESIK:ACE: DELETE BEAM MODEL: "+template+"-"+program+": SUCCESS")
-    return &bi, nil
+// ----------------------------------------------------------------------
+// LIST BEAM METRICS ( )
+// ----------------------------------------------------------------------
+// Lists the monitoring metrics of the account, such as memory:free or
+// load:average:1, which may be collected by the probes of BEAM documents.
+// ----------------------------------------------------------------------
+func (c *Client) ListBeamMetrics(ctx context.Context) ([]BeamMetric, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM METRICS: "+c.account)
+    reqBody := map[string]string{"action": "list", "subject": "metric" }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list metrics")
+    if err != nil {
+        return nil, err
+    }
+
+    var metrics []BeamMetric
+    if err := beamResult(bodyBytes, &metrics); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM METRICS: "+c.account+": SUCCESS")
+    return metrics, nil
+}
+
+// ----------------------------------------------------------------------
+// LIST BEAM NODE TYPES ( )
+// ----------------------------------------------------------------------
+// Lists the TOSCA node types the account may import or use as the type
+// of BEAM software nodes, with their life cycle scripts and ports.
+// ----------------------------------------------------------------------
+func (c *Client) ListBeamNodeTypes(ctx context.Context) ([]BeamNodeTypeDefinition, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM NODE TYPES: "+c.account)
+    reqBody := map[string]string{"action": "list", "subject": "type" }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to list node types")
+    if err != nil {
+        return nil, err
+    }
+
+    var nodeTypes []BeamNodeTypeDefinition
+    if err := beamResult(bodyBytes, &nodeTypes); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: LIST BEAM NODE TYPES: "+c.account+": SUCCESS")
+    return nodeTypes, nil
+}
+
+// returns the request parameters describing a node type
+func nodeTypeRequest(action string, t BeamNodeTypeDefinition) map[string]string {
+    return map[string]string{"action": action, "subject": "type", "name": t.Name,
+        "create": t.Create, "start": t.Start, "stop": t.Stop, "save": t.Save, "delete": t.Delete,
+        "tcp_port": strings.Join(t.TcpPorts, ","), "udp_port": strings.Join(t.UdpPorts, ",") }
+}
+
+// ----------------------------------------------------------------------
+// CREATE BEAM NODE TYPE ( DEFINITION )
+// ----------------------------------------------------------------------
+// Registers a reusable node type at account level, which BEAM documents
+// may then import by name rather than defining it as a local type.
+// ----------------------------------------------------------------------
+func (c *Client) CreateBeamNodeType(ctx context.Context, t BeamNodeTypeDefinition) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM NODE TYPE: "+t.Name)
+    bodyBytes, err := c.beamRequest(ctx, nodeTypeRequest("create", t), "failed to create node type")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM NODE TYPE: "+t.Name+": SUCCESS")
+    return nil
+}
+
+// ----------------------------------------------------------------------
+// GET BEAM NODE TYPE ( NAME )
+// ----------------------------------------------------------------------
+// Returns the definition of an account level node type, failing with
+// ErrBeamNotFound when no such node type exists.
+// ----------------------------------------------------------------------
+func (c *Client) GetBeamNodeType(ctx context.Context, name string) (*BeamNodeTypeDefinition, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: GET BEAM NODE TYPE: "+name)
+    reqBody := map[string]string{"action": "get", "subject": "type", "name": name }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to get node type")
+    if err != nil {
+        return nil, err
+    }
+
+    var t BeamNodeTypeDefinition
+    if err := beamResult(bodyBytes, &t); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: GET BEAM NODE TYPE: "+name+": SUCCESS")
+    return &t, nil
+}
+
+// ----------------------------------------------------------------------
+// UPDATE BEAM NODE TYPE ( DEFINITION )
+// ----------------------------------------------------------------------
+func (c *Client) UpdateBeamNodeType(ctx context.Context, t BeamNodeTypeDefinition) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UPDATE BEAM NODE TYPE: "+t.Name)
+    bodyBytes, err := c.beamRequest(ctx, nodeTypeRequest("update", t), "failed to update node type")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UPDATE BEAM NODE TYPE: "+t.Name+": SUCCESS")
+    return nil
+}
+
+// ----------------------------------------------------------------------
+// DELETE BEAM NODE TYPE ( NAME )
+// ----------------------------------------------------------------------
+func (c *Client) DeleteBeamNodeType(ctx context.Context, name string) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM NODE TYPE: "+name)
+    reqBody := map[string]string{"action": "delete", "subject": "type", "name": name }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to delete node type")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM NODE TYPE: "+name+": SUCCESS")
+    return nil
+}
+
+// returns the request parameters describing a probe definition
+func probeRequest(action string, p BeamProbeDefinition) map[string]string {
+    return map[string]string{"action": action, "subject": "probe", "name": p.Name,
+        "metric": p.Metric, "condition": p.Condition, "threshold": p.Threshold,
+        "type": p.Type, "nature": p.Nature, "behaviour": p.Behaviour }
+}
+
+// ----------------------------------------------------------------------
+// CREATE BEAM PROBE ( DEFINITION )
+// ----------------------------------------------------------------------
+// Registers a monitoring probe definition at account level, which the
+// Probe tags of BEAM documents may then name.
+// ----------------------------------------------------------------------
+func (c *Client) CreateBeamProbe(ctx context.Context, p BeamProbeDefinition) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM PROBE: "+p.Name)
+    bodyBytes, err := c.beamRequest(ctx, probeRequest("create", p), "failed to create probe")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: CREATE BEAM PROBE: "+p.Name+": SUCCESS")
+    return nil
+}
+
+// ----------------------------------------------------------------------
+// GET BEAM PROBE ( NAME )
+// ----------------------------------------------------------------------
+// Returns an account level probe definition, failing with
+// ErrBeamNotFound when no such probe exists.
+// ----------------------------------------------------------------------
+func (c *Client) GetBeamProbe(ctx context.Context, name string) (*BeamProbeDefinition, error) {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: GET BEAM PROBE: "+name)
+    reqBody := map[string]string{"action": "get", "subject": "probe", "name": name }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to get probe")
+    if err != nil {
+        return nil, err
+    }
+
+    var p BeamProbeDefinition
+    if err := beamResult(bodyBytes, &p); err != nil {
+        return nil, err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: GET BEAM PROBE: "+name+": SUCCESS")
+    return &p, nil
+}
+
+// ----------------------------------------------------------------------
+// UPDATE BEAM PROBE ( DEFINITION )
+// ----------------------------------------------------------------------
+func (c *Client) UpdateBeamProbe(ctx context.Context, p BeamProbeDefinition) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UPDATE BEAM PROBE: "+p.Name)
+    bodyBytes, err := c.beamRequest(ctx, probeRequest("update", p), "failed to update probe")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: UPDATE BEAM PROBE: "+p.Name+": SUCCESS")
+    return nil
 }
 
+// ----------------------------------------------------------------------
+// DELETE BEAM PROBE ( NAME )
+// ----------------------------------------------------------------------
+func (c *Client) DeleteBeamProbe(ctx context.Context, name string) error {
+    ctx = c.logContext(ctx)
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM PROBE: "+name)
+    reqBody := map[string]string{"action": "delete", "subject": "probe", "name": name }
+    bodyBytes, err := c.beamRequest(ctx, reqBody, "failed to delete probe")
+    if err != nil {
+        return err
+    }
+    if err := beamResult(bodyBytes, nil); err != nil {
+        return err
+    }
+    tflog.SubsystemInfo(ctx, clientLogSubsystem, "AMENESIK:ACE: DELETE BEAM PROBE: "+name+": SUCCESS")
+    return nil
+}
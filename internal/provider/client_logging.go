@@ -0,0 +1,136 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// CLIENT LOGGING
+// -------------------------------------------
+// The ACE client logs through the dedicated
+// amenesik_client subsystem, controlled by
+// TF_LOG_PROVIDER_AMENESIK_CLIENT. Credentials
+// and sensitive BEAM values are always masked
+// in the messages and fields of the client
+// subsystem and of the provider logger.
+// -------------------------------------------
+
+package provider
+
+import (
+    "context"
+    "regexp"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// the name of the ACE client logging subsystem
+const clientLogSubsystem = "amenesik_client"
+
+// the log field keys whose values are always masked
+var sensitiveLogKeys = []string{
+    "amenesik_apikey", "apikey", "secret", "auth", "token", "authorization", "Authorization",
+}
+
+// the credentials of JSON request and response bodies and authorization headers
+var sensitiveLogPatterns = []*regexp.Regexp{
+    regexp.MustCompile(`"(auth|secret|token|apikey)"\s*:\s*"[^"]*"`),
+    regexp.MustCompile(`Bearer\s+\S+`),
+}
+
+// the length below which credentials are not masked wherever they appear,
+// which would garble the log output, but only by their field keys
+const minLogSecretLength = 8
+
+// the context key of the additional values to be masked
+type logSecretsKey struct{}
+
+// the context key of every secret value, whatever its length
+type scrubSecretsKey struct{}
+
+// returns the values to be masked that were added to the context
+func logSecrets(ctx context.Context) []string {
+    values, _ := ctx.Value(logSecretsKey{}).([]string)
+    return values
+}
+
+// returns every secret value added to the context, including the
+// credentials too short to be masked in the log output, such as the
+// values to be scrubbed from recorded cassettes
+func scrubSecrets(ctx context.Context) []string {
+    values, _ := ctx.Value(scrubSecretsKey{}).([]string)
+    return values
+}
+
+// returns the values that are not empty
+func nonEmptySecrets(values []string) []string {
+    var secrets []string
+    for _, v := range values {
+        if v != "" {
+            secrets = append(secrets, v)
+        }
+    }
+    return secrets
+}
+
+// ----------------------------------------------------------------------
+// WITH LOG SECRETS ( values )
+// ----------------------------------------------------------------------
+// Returns the context masking the credentials, such as the API KEY, in
+// the provider log output and in the log output of any client subsystem
+// subsequently derived from the context. Values shorter than
+// minLogSecretLength are only masked by their field keys.
+// ----------------------------------------------------------------------
+func withLogSecrets(ctx context.Context, values ...string) context.Context {
+    var secrets []string
+    for _, v := range values {
+        if len(v) >= minLogSecretLength {
+            secrets = append(secrets, v)
+        }
+    }
+    return maskLogSecrets(ctx, secrets, nonEmptySecrets(values))
+}
+
+// ----------------------------------------------------------------------
+// WITH LOG SECRET DATA ( values )
+// ----------------------------------------------------------------------
+// Returns the context masking the secret BEAM data values in the same
+// way as credentials, but whatever their length, as these values appear
+// in the messages of the changes rather than under field keys.
+// ----------------------------------------------------------------------
+func withLogSecretData(ctx context.Context, values ...string) context.Context {
+    secrets := nonEmptySecrets(values)
+    return maskLogSecrets(ctx, secrets, secrets)
+}
+
+// returns the context masking the values and those added to the context,
+// and scrubbing the secrets and those added to the context
+func maskLogSecrets(ctx context.Context, values []string, scrubbed []string) context.Context {
+    secrets := append(append([]string{}, logSecrets(ctx)...), values...)
+    ctx = context.WithValue(ctx, logSecretsKey{}, secrets)
+    ctx = context.WithValue(ctx, scrubSecretsKey{}, append(append([]string{}, scrubSecrets(ctx)...), scrubbed...))
+    ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogKeys...)
+    ctx = tflog.MaskMessageRegexes(ctx, sensitiveLogPatterns...)
+    ctx = tflog.MaskAllFieldValuesRegexes(ctx, sensitiveLogPatterns...)
+    if len(secrets) > 0 {
+        ctx = tflog.MaskLogStrings(ctx, secrets...)
+    }
+    return ctx
+}
+
+// ----------------------------------------------------------------------
+// CLIENT LOG CONTEXT ( values )
+// ----------------------------------------------------------------------
+// Returns the context of the client logging subsystem, masking the
+// credentials and the values added to the context in its output.
+// ----------------------------------------------------------------------
+func clientLogContext(ctx context.Context, values ...string) context.Context {
+    ctx = withLogSecrets(ctx, values...)
+    ctx = tflog.NewSubsystem(ctx, clientLogSubsystem)
+    ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, clientLogSubsystem, sensitiveLogKeys...)
+    ctx = tflog.SubsystemMaskMessageRegexes(ctx, clientLogSubsystem, sensitiveLogPatterns...)
+    ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, clientLogSubsystem, sensitiveLogPatterns...)
+    if secrets := logSecrets(ctx); len(secrets) > 0 {
+        ctx = tflog.SubsystemMaskLogStrings(ctx, clientLogSubsystem, secrets...)
+    }
+    return ctx
+}
+
+// returns the client logging context masking the client credentials
+func (c *Client) logContext(ctx context.Context) context.Context {
+    return clientLogContext(ctx, c.apikey, c.token)
+}
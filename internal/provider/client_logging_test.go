@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClientLogContext(t *testing.T) {
+	tests := map[string]struct {
+		values  []string
+		message string
+		fields  map[string]interface{}
+		logged  string
+		field   interface{}
+	}{
+		"secret value": {
+			values:  []string{"recorded-apikey"},
+			message: "login with recorded-apikey",
+			logged:  "login with ***",
+		},
+		"short value": {
+			values:  []string{"1"},
+			message: "node.1.num_cpus:1",
+			logged:  "node.1.num_cpus:1",
+		},
+		"short credential field": {
+			values:  []string{"key"},
+			message: "login",
+			fields:  map[string]interface{}{"apikey": "key"},
+			logged:  "login",
+			field:   "***",
+		},
+		"request body": {
+			message: `{"action":"login","user":"test","secret":"key"}`,
+			logged:  `{"action":"login","user":"test",***}`,
+		},
+		"authorization header": {
+			message: "Authorization: Bearer 0123abcd",
+			logged:  "Authorization: ***",
+		},
+		"body field": {
+			message: "response",
+			fields:  map[string]interface{}{"body": `{"status":"200","auth":"0123abcd"}`},
+			logged:  "response",
+			field:   `{"status":"200",***}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var output bytes.Buffer
+			ctx := clientLogContext(tflogtest.RootLogger(context.Background(), &output), test.values...)
+			tflog.SubsystemInfo(ctx, clientLogSubsystem, test.message, test.fields)
+			tflog.Info(ctx, test.message, test.fields)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 2 {
+				t.Fatalf("expected 2 log entries, got %v", entries)
+			}
+			for _, entry := range entries {
+				if entry["@message"] != test.logged {
+					t.Errorf("expected the message %q, got %q", test.logged, entry["@message"])
+				}
+				for key := range test.fields {
+					if entry[key] != test.field {
+						t.Errorf("expected the field %s %v, got %v", key, test.field, entry[key])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWithLogSecrets(t *testing.T) {
+	ctx := withLogSecrets(context.Background(), "recorded-apikey", "", "short")
+	ctx = withLogSecrets(ctx, "s3cret-db-pass")
+	secrets := logSecrets(ctx)
+	if len(secrets) != 2 || secrets[0] != "recorded-apikey" || secrets[1] != "s3cret-db-pass" {
+		t.Errorf("unexpected secrets %v", secrets)
+	}
+	// the short values are still scrubbed from cassettes
+	scrubbed := scrubSecrets(ctx)
+	if len(scrubbed) != 3 || scrubbed[0] != "recorded-apikey" || scrubbed[1] != "short" || scrubbed[2] != "s3cret-db-pass" {
+		t.Errorf("unexpected scrubbed secrets %v", scrubbed)
+	}
+}
+
+func TestWithLogSecretData(t *testing.T) {
+	ctx := withLogSecrets(context.Background(), "recorded-apikey", "short")
+	ctx = withLogSecretData(ctx, "mypass", "", "s3cret-db-pass")
+	secrets := logSecrets(ctx)
+	if len(secrets) != 3 || secrets[0] != "recorded-apikey" || secrets[1] != "mypass" || secrets[2] != "s3cret-db-pass" {
+		t.Errorf("unexpected secrets %v", secrets)
+	}
+}
+
+func TestWithLogSecretDataMessage(t *testing.T) {
+	var output bytes.Buffer
+	ctx := withLogSecretData(tflogtest.RootLogger(context.Background(), &output), "mypass")
+	tflog.Info(ctx, "AMENESIK:BEAM CHANGE: node.1.db.PASS:mypass")
+	c := &Client{account: "test", apikey: "key"}
+	tflog.SubsystemInfo(c.logContext(ctx), clientLogSubsystem, "AMENESIK:ACE: CHANGE: mypass with key")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %v", entries)
+	}
+	if entries[0]["@message"] != "AMENESIK:BEAM CHANGE: node.1.db.PASS:***" {
+		t.Errorf("expected the short secret to be masked, got %q", entries[0]["@message"])
+	}
+	// the short API KEY is only masked by its field key
+	if entries[1]["@message"] != "AMENESIK:ACE: CHANGE: *** with key" {
+		t.Errorf("expected only the short secret to be masked, got %q", entries[1]["@message"])
+	}
+}
+
+func TestProviderSchemaSensitive(t *testing.T) {
+	var resp provider.SchemaResponse
+	New("test")().Schema(context.Background(), provider.SchemaRequest{}, &resp)
+	for _, name := range []string{"apikey", "token"} {
+		if !resp.Schema.Attributes[name].(schema.StringAttribute).Sensitive {
+			t.Errorf("expected the %s attribute to be sensitive", name)
+		}
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+// testServer returns the URL of a server answering the login and the
+// other requests with the status and body returned by the handler.
+func testServer(t *testing.T, handler func(req map[string]string) (int, string)) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req["action"] == "login" {
+			w.Write([]byte(`{"status":"200","auth":"token","account":"test"}`))
+			return
+		}
+		status, body := handler(req)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// testClient returns a client of the server of the handler.
+func testClient(t *testing.T, handler func(req map[string]string) (int, string)) *Client {
+	t.Helper()
+	c, err := NewClient(context.Background(), testServer(t, handler), "test", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestUnQuote(t *testing.T) {
+	tests := map[string]string{
+		``:          ``,
+		`"`:         `"`,
+		`""`:        ``,
+		`"web"`:     `web`,
+		`web`:       `web`,
+		`"web`:      `"web`,
+		`w`:         `w`,
+		`"a "b" c"`: `a "b" c`,
+	}
+	for v, expected := range tests {
+		if got := UnQuote(v); got != expected {
+			t.Errorf("UnQuote(%q): expected %q, got %q", v, expected, got)
+		}
+	}
+}
+
+func TestBeamModelName(t *testing.T) {
+	tests := []struct {
+		template string
+		program  string
+		expected string
+		ok       bool
+	}{
+		{"example-rhel9-template", "small-template", "example-rhel9-small-template", true},
+		{"webtemplate", "shop", "webshop", true},
+		{"template", "shop", "shop", true},
+		{"webtemplate", "", "web", true},
+		{"webbase", "shop", "", false},
+		{"", "shop", "", false},
+		{"", "", "", false},
+		{"template-web", "shop", "", false},
+	}
+	for _, test := range tests {
+		name, ok := BeamModelName(test.template, test.program)
+		if name != test.expected || ok != test.ok {
+			t.Errorf("BeamModelName(%q, %q): expected %q %t, got %q %t", test.template, test.program, test.expected, test.ok, name, ok)
+		}
+	}
+}
+
+func TestBeamJsonParser(t *testing.T) {
+	tests := []struct {
+		js       string
+		name     string
+		expected string
+	}{
+		{`{"status":"200","id":"webshop"}`, "id", "webshop"},
+		{`{"status":200}`, "status", "200"},
+		{`{"status":1}`, "status", "1"},
+		{`{"lock":true}`, "lock", "true"},
+		{`{"status":"a,b:c"}`, "status", "a,b:c"},
+		{`{"id":"x"}`, "status", "none"},
+		{`{"id":null}`, "id", "none"},
+		{`{"result":{"id":"x"}}`, "id", "none"},
+		{`{}`, "id", "none"},
+		{``, "id", "none"},
+		{`x`, "id", "none"},
+		{`[1]`, "id", "none"},
+	}
+	for _, test := range tests {
+		if got := BeamJsonParser(test.js, test.name); got != test.expected {
+			t.Errorf("BeamJsonParser(%q, %q): expected %q, got %q", test.js, test.name, test.expected, got)
+		}
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	tests := map[string]bool{
+		"yes":    true,
+		"YES":    true,
+		"locked": true,
+		"true":   true,
+		"1":      true,
+		"no":     false,
+		"false":  false,
+		"0":      false,
+		"none":   false,
+		"":       false,
+	}
+	for lock, expected := range tests {
+		if got := isLocked(lock); got != expected {
+			t.Errorf("isLocked(%q): expected %t, got %t", lock, expected, got)
+		}
+	}
+}
+
+func TestStatusBeamInstance(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		status   string
+		lock     bool
+		category string
+		region   string
+	}{
+		"strings":    {`{"status":"started","id":"webshop","lock":"yes","provider":"amazonec2","region":"france"}`, "started", true, "amazonec2", "france"},
+		"non string": {`{"status":2,"id":7,"lock":true,"provider":"amazonec2","region":"france"}`, "2", true, "amazonec2", "france"},
+		"one digit":  {`{"status":"created","lock":0}`, "created", false, "none", "none"},
+		"separators": {`{"status":"started","region":"eu-west,1","provider":"a:b"}`, "started", false, "a:b", "eu-west,1"},
+		"none":       {`{"status":"none"}`, "none", false, "none", "none"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(map[string]string) (int, string) { return http.StatusOK, test.body })
+			br, err := c.StatusBeamInstance(context.Background(), `"webtemplate"`, `"shop"`, `"example.com"`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if br.status != test.status || isLocked(br.result.lock) != test.lock || br.result.category != test.category || br.result.region != test.region {
+				t.Errorf("unexpected status %+v", *br)
+			}
+		})
+	}
+}
+
+func TestCloneBeamModel(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		body     string
+		expected string
+	}{
+		"derived":         {`"webtemplate"`, `{"status":"200","id":"beam7"}`, "webshop"},
+		"derived no id":   {`"webtemplate"`, `{"status":"200"}`, "webshop"},
+		"assigned":        {`"webbase"`, `{"status":"200","id":"beam7"}`, "beam7"},
+		"assigned number": {`"webbase"`, `{"status":"200","id":7}`, "7"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(map[string]string) (int, string) { return http.StatusOK, test.body })
+			br, err := c.CloneBeamModel(context.Background(), test.template, `"shop"`, `"example.com"`, `"france"`, `"amazonec2"`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if br.result.name != test.expected {
+				t.Errorf("expected the model name %q, got %q", test.expected, br.result.name)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+		code   int
+		error  string
+	}{
+		"http status":      {status: http.StatusBadGateway, body: "failure", code: http.StatusBadGateway, error: "failed to list metrics: 502 Bad Gateway"},
+		"body status":      {status: http.StatusOK, body: `{"status":"401","message":"expired"}`, code: http.StatusUnauthorized, error: "failed with status 401: expired"},
+		"non numeric body": {status: http.StatusOK, body: `{"status":"failed","message":"error 401"}`, error: "failed with status failed: error 401"},
+		"success":          {status: http.StatusOK, body: `{"status":"200","result":[]}`},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(map[string]string) (int, string) { return test.status, test.body })
+			_, err := c.ListBeamMetrics(context.Background())
+			if got := fmt.Sprint(err); (err != nil || test.error != "") && got != test.error {
+				t.Errorf("expected the error %q, got %v", test.error, err)
+			}
+			if code := httpStatusCode(err); code != test.code {
+				t.Errorf("expected the status %d, got %d", test.code, code)
+			}
+		})
+	}
+}
+
+func TestWhoAmI(t *testing.T) {
+	valid := fmt.Sprint(time.Now().Add(time.Hour).Unix())
+	tests := map[string]struct {
+		status  int
+		body    string
+		expired bool
+		error   bool
+		role    string
+	}{
+		"valid token":           {status: http.StatusOK, body: `{"status":"200","result":{"account":"test","user":"sso","role":"admin","expires":"` + valid + `"}}`, role: "admin"},
+		"flat response":         {status: http.StatusOK, body: `{"status":"200","account":"test","role":"user"}`, role: "user"},
+		"unauthorized":          {status: http.StatusUnauthorized, body: "unauthorized", expired: true},
+		"forbidden":             {status: http.StatusForbidden, body: "forbidden", expired: true},
+		"unauthorized body":     {status: http.StatusOK, body: `{"status":"401","message":"token revoked"}`, expired: true},
+		"expiry passed":         {status: http.StatusOK, body: `{"status":"200","result":{"account":"test","expires":"2001-02-03T04:05:06Z"}}`, expired: true},
+		"server failure":        {status: http.StatusInternalServerError, body: "failure", error: true},
+		"failure mentions 401":  {status: http.StatusOK, body: `{"status":"500","message":"gateway 401 unreachable"}`, error: true},
+		"failure mentions 4031": {status: http.StatusServiceUnavailable, body: "retry after 4031 ms", error: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			url := testServer(t, func(req map[string]string) (int, string) {
+				if req["action"] != "whoami" || req["auth"] != "pre-issued-token" {
+					return http.StatusBadRequest, "unexpected request"
+				}
+				return test.status, test.body
+			})
+			c, err := NewClientWithToken(context.Background(), url, "", "pre-issued-token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			session, err := c.WhoAmI(context.Background())
+			if errors.Is(err, ErrBeamTokenExpired) != test.expired || (err != nil) != (test.expired || test.error) {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if err == nil && (session.auth != "pre-issued-token" || session.account != "test" || session.role != test.role) {
+				t.Errorf("unexpected session %+v", session)
+			}
+		})
+	}
+}
+
+func TestParseBeamExpiry(t *testing.T) {
+	tests := map[string]struct {
+		expires  string
+		expected time.Time
+		ok       bool
+	}{
+		"seconds":      {"1700000000", time.Unix(1700000000, 0), true},
+		"milliseconds": {"1700000000123", time.UnixMilli(1700000000123), true},
+		"RFC 3339":     {" 2030-01-02T03:04:05Z ", time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), true},
+		"date":         {"2030-01-02", time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		"empty":        {"", time.Time{}, false},
+		"invalid":      {"tomorrow", time.Time{}, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expiry, ok := ParseBeamExpiry(test.expires)
+			if ok != test.ok || !expiry.Equal(test.expected) {
+				t.Errorf("expected %v %t, got %v %t", test.expected, test.ok, expiry, ok)
+			}
+		})
+	}
+}
+
+func TestLazyAuthentication(t *testing.T) {
+	ctx := context.Background()
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+
+	// the creation of the client performs no request
+	c, err := NewClient(ctx, sim.URL(), "test", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.Requests("login") != 0 {
+		t.Fatalf("expected no login before the first request")
+	}
+
+	// the concurrent requests share the session of a single login
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.ListBeamTemplates(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if logins := sim.Requests("login"); logins != 1 {
+		t.Errorf("expected a single login, got %d", logins)
+	}
+}
+
+func TestLazyAuthenticationFailure(t *testing.T) {
+	ctx := context.Background()
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	c, err := NewClient(ctx, sim.URL(), "test", "apikey")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a failed login is not retained and is retried by the next request
+	sim.Fail("login", http.StatusServiceUnavailable)
+	if _, err := c.ListBeamTemplates(ctx); err == nil {
+		t.Fatal("expected the failed login to fail the request")
+	}
+	if _, err := c.ListBeamTemplates(ctx); err != nil {
+		t.Fatalf("expected the login to be retried, got %v", err)
+	}
+	if logins := sim.Requests("login"); logins != 2 {
+		t.Errorf("expected 2 logins, got %d", logins)
+	}
+}
+
+func TestLazyAuthenticationToken(t *testing.T) {
+	ctx := context.Background()
+	url := testServer(t, func(req map[string]string) (int, string) {
+		switch req["action"] {
+		case "whoami":
+			return http.StatusOK, `{"status":"200","result":{"account":"sso-account"}}`
+		case "list":
+			if req["account"] != "sso-account" {
+				return http.StatusBadRequest, "unexpected account"
+			}
+			return http.StatusOK, `{"status":"200","result":[]}`
+		}
+		return http.StatusBadRequest, "unexpected request"
+	})
+	c, err := NewClientWithToken(ctx, url, "", "pre-issued-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the account of the token is that returned by the whoami action
+	if _, err := c.ListBeamTemplates(ctx); err != nil {
+		t.Fatal(err)
+	}
+	session, err := c.Session(ctx)
+	if err != nil || session.account != "sso-account" || session.auth != "pre-issued-token" {
+		t.Errorf("unexpected session %+v %v", session, err)
+	}
+}
+
+func TestProviderConfigureOffline(t *testing.T) {
+	t.Setenv("ACE_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "missing"))
+	// the provider is configured without contacting the unreachable host
+	testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, "http://127.0.0.1:1"),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+}
+
+func TestProviderConfigureSimulator(t *testing.T) {
+	t.Setenv("ACE_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "missing"))
+	t.Cleanup(func() { simulator.Remove("configure") })
+	attributes := map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, simulator.Scheme+"configure"),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	}
+
+	// the simulator hosts are refused unless enabled
+	for _, value := range []string{"", "false", "yes"} {
+		t.Setenv(simulator.EnableVariable, value)
+		if _, err := testProviderConfigure(t, attributes); err != "Amenesik Simulator Not Enabled" {
+			t.Errorf("expected the simulator to be refused for %q, got %q", value, err)
+		}
+	}
+
+	t.Setenv(simulator.EnableVariable, "true")
+	c, err := testProviderConfigure(t, attributes)
+	if err != "" {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(c.baseURL, simulator.Named("configure").URL()) {
+		t.Errorf("expected the client of the simulator, got %s", c.baseURL)
+	}
+}
@@ -0,0 +1,172 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// PROVIDER CREDENTIALS
+// -------------------------------------------
+// Credentials may be provided by the named
+// profiles of a shared credentials file, by
+// default ~/.amenesik/credentials, or by an
+// external credential process writing them
+// as a JSON object to its standard output.
+// -------------------------------------------
+
+package provider
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "runtime"
+    "strings"
+)
+
+// the name of the profile used when none is configured
+const defaultProfile = "default"
+
+// returned when the credentials file does not define the profile
+var errProfileNotFound = errors.New("profile not found")
+
+// ---------------------------------
+// ACE provider credentials
+// ---------------------------------
+type beamCredentials struct {
+    Host              string `json:"host"`
+    Account           string `json:"account"`
+    ApiKey            string `json:"apikey"`
+    Token             string `json:"token"`
+    CredentialProcess string `json:"-"`
+}
+
+// merges the credentials, values already set taking precedence
+func (c *beamCredentials) merge(o beamCredentials) {
+    if c.Host == "" {
+        c.Host = o.Host
+    }
+    if c.Account == "" {
+        c.Account = o.Account
+    }
+    if c.ApiKey == "" {
+        c.ApiKey = o.ApiKey
+    }
+    if c.Token == "" {
+        c.Token = o.Token
+    }
+    if c.CredentialProcess == "" {
+        c.CredentialProcess = o.CredentialProcess
+    }
+}
+
+// returns the path of the shared credentials file
+func credentialsFile() string {
+    if f := os.Getenv("ACE_CREDENTIALS_FILE"); f != "" {
+        return f
+    }
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return ""
+    }
+    return filepath.Join(home, ".amenesik", "credentials")
+}
+
+// ----------------------------------------------------------------------
+// LOAD PROFILE ( file, profile )
+// ----------------------------------------------------------------------
+// Returns the credentials of the named profile of an INI formatted
+// credentials file, whose sections name the profiles and whose keys are
+// host, account, apikey, token and credential_process. A missing file yields
+// os.ErrNotExist and a missing profile errProfileNotFound.
+// ----------------------------------------------------------------------
+func loadProfile(file string, profile string) (beamCredentials, error) {
+    var creds beamCredentials
+    if file == "" {
+        return creds, os.ErrNotExist
+    }
+    f, err := os.Open(file)
+    if err != nil {
+        return creds, err
+    }
+    defer f.Close()
+
+    found := false
+    section := ""
+    scanner := bufio.NewScanner(f)
+    for number := 1; scanner.Scan(); number++ {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+            continue
+        }
+        if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+            section = strings.TrimSpace(line[1 : len(line)-1])
+            section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+            if section == profile {
+                found = true
+            }
+            continue
+        }
+        key, value, ok := strings.Cut(line, "=")
+        if !ok {
+            return creds, fmt.Errorf("%s: line %d: expected key = value", file, number)
+        }
+        if section != profile {
+            continue
+        }
+        value = strings.TrimSpace(value)
+        switch strings.ToLower(strings.TrimSpace(key)) {
+        case "host":
+            creds.Host = value
+        case "account":
+            creds.Account = value
+        case "apikey":
+            creds.ApiKey = value
+        case "token":
+            creds.Token = value
+        case "credential_process":
+            creds.CredentialProcess = value
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        return creds, err
+    }
+    if !found {
+        return creds, fmt.Errorf("%s: %q: %w", file, profile, errProfileNotFound)
+    }
+    return creds, nil
+}
+
+// ----------------------------------------------------------------------
+// RUN CREDENTIAL PROCESS ( command )
+// ----------------------------------------------------------------------
+// Runs the external command through the system shell, such as a vault
+// command line tool, returning the credentials written to its standard
+// output as a JSON object with host, account, apikey and token members.
+// ----------------------------------------------------------------------
+func runCredentialProcess(ctx context.Context, command string) (beamCredentials, error) {
+    var creds beamCredentials
+    var cmd *exec.Cmd
+    if runtime.GOOS == "windows" {
+        cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+    } else {
+        cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+    }
+    var stdout, stderr bytes.Buffer
+    cmd.Stdout = &stdout
+    cmd.Stderr = &stderr
+    if err := cmd.Run(); err != nil {
+        if msg := strings.TrimSpace(stderr.String()); msg != "" {
+            return creds, fmt.Errorf("%s: %s", err.Error(), msg)
+        }
+        return creds, err
+    }
+    if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+        return creds, fmt.Errorf("invalid credential process output: %s", err.Error())
+    }
+    if creds == (beamCredentials{}) {
+        return creds, errors.New("the credential process returned no credentials")
+    }
+    return creds, nil
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// the credentials file of the profile tests
+const testCredentialsFile = `# the shared credentials
+[default]
+host    = ace.example.com
+account = shop
+apikey  = default-apikey
+
+; a named profile
+[profile production]
+account            = production
+credential_process = vault read -field=json ace/production
+
+[token]
+HOST  = token.example.com
+token = pre-issued=token
+`
+
+func TestLoadProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte(testCredentialsFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		profile  string
+		expected beamCredentials
+		error    error
+	}{
+		"default": {
+			profile:  "default",
+			expected: beamCredentials{Host: "ace.example.com", Account: "shop", ApiKey: "default-apikey"},
+		},
+		"profile prefix": {
+			profile:  "production",
+			expected: beamCredentials{Account: "production", CredentialProcess: "vault read -field=json ace/production"},
+		},
+		"token and key case": {
+			profile:  "token",
+			expected: beamCredentials{Host: "token.example.com", Token: "pre-issued=token"},
+		},
+		"missing profile": {
+			profile: "staging",
+			error:   errProfileNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			creds, err := loadProfile(file, test.profile)
+			if !errors.Is(err, test.error) {
+				t.Fatalf("expected the error %v, got %v", test.error, err)
+			}
+			if err == nil && creds != test.expected {
+				t.Errorf("expected the credentials %+v, got %+v", test.expected, creds)
+			}
+		})
+	}
+}
+
+func TestLoadProfileFailures(t *testing.T) {
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed")
+	if err := os.WriteFile(malformed, []byte("[default]\nhost = ace.example.com\napikey\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadProfile(malformed, "default"); err == nil || !strings.Contains(err.Error(), "line 3: expected key = value") {
+		t.Errorf("expected a malformed line error, got %v", err)
+	}
+	for _, file := range []string{"", filepath.Join(dir, "missing")} {
+		if _, err := loadProfile(file, "default"); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected a missing file error for %q, got %v", file, err)
+		}
+	}
+}
+
+func TestCredentialsFile(t *testing.T) {
+	t.Setenv("ACE_CREDENTIALS_FILE", "/etc/amenesik/credentials")
+	if got := credentialsFile(); got != "/etc/amenesik/credentials" {
+		t.Errorf("unexpected credentials file %s", got)
+	}
+	t.Setenv("ACE_CREDENTIALS_FILE", "")
+	t.Setenv("HOME", "/home/ace")
+	if got := credentialsFile(); runtime.GOOS != "windows" && got != filepath.Join("/home/ace", ".amenesik", "credentials") {
+		t.Errorf("unexpected credentials file %s", got)
+	}
+}
+
+func TestBeamCredentialsMerge(t *testing.T) {
+	creds := beamCredentials{Host: "ace.example.com"}
+	creds.merge(beamCredentials{Host: "other.example.com", Account: "shop", ApiKey: "apikey"})
+	creds.merge(beamCredentials{Account: "other", Token: "token", CredentialProcess: "vault"})
+	expected := beamCredentials{Host: "ace.example.com", Account: "shop", ApiKey: "apikey", Token: "token", CredentialProcess: "vault"}
+	if creds != expected {
+		t.Errorf("expected the credentials %+v, got %+v", expected, creds)
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the credential process commands are shell commands")
+	}
+	tests := map[string]struct {
+		command  string
+		expected beamCredentials
+		error    string
+	}{
+		"credentials": {
+			command:  `echo '{"host":"ace.example.com","account":"shop","apikey":"process-apikey","other":1}'`,
+			expected: beamCredentials{Host: "ace.example.com", Account: "shop", ApiKey: "process-apikey"},
+		},
+		"token": {
+			command:  `printf '{"token":"pre-issued-token"}'`,
+			expected: beamCredentials{Token: "pre-issued-token"},
+		},
+		"failure": {
+			command: `echo "vault sealed" >&2; exit 2`,
+			error:   "exit status 2: vault sealed",
+		},
+		"silent failure": {
+			command: `exit 1`,
+			error:   "exit status 1",
+		},
+		"invalid output": {
+			command: `echo apikey`,
+			error:   "invalid credential process output",
+		},
+		"no credentials": {
+			command: `echo '{}'`,
+			error:   "the credential process returned no credentials",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			creds, err := runCredentialProcess(context.Background(), test.command)
+			if test.error != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.error) {
+					t.Fatalf("expected the error %q, got %v", test.error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if creds != test.expected {
+				t.Errorf("expected the credentials %+v, got %+v", test.expected, creds)
+			}
+		})
+	}
+}
+
+// testProviderConfigure configures the provider with the attributes, returning
+// the client and the summary of the first error.
+func testProviderConfigure(t *testing.T, attributes map[string]tftypes.Value) (*Client, string) {
+	t.Helper()
+	ctx := context.Background()
+	p := New("test")()
+	var schema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schema)
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	config := tfsdk.Config{Schema: schema.Schema, Raw: testObjectValue(objectType, attributes)}
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, &resp)
+	for _, d := range resp.Diagnostics.Errors() {
+		return nil, d.Summary()
+	}
+	return resp.ResourceData.(*Client), ""
+}
+
+func TestProviderConfigureCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the credential process commands are shell commands")
+	}
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte(testCredentialsFile+`
+[process]
+host               = process.example.com
+account            = profiled
+credential_process = echo '{"account":"processed","apikey":"process-apikey","host":"preferred.example.com"}'
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		env        map[string]string
+		attributes map[string]tftypes.Value
+		expected   beamCredentials
+		error      string
+	}{
+		"default profile": {
+			expected: beamCredentials{Host: "https://ace.example.com/aec/api.php", Account: "shop", ApiKey: "default-apikey"},
+		},
+		"configuration first": {
+			env: map[string]string{"ACE_ACCOUNT": "environment"},
+			attributes: map[string]tftypes.Value{
+				"account": tftypes.NewValue(tftypes.String, "configured"),
+				"apikey":  tftypes.NewValue(tftypes.String, "configured-apikey"),
+			},
+			expected: beamCredentials{Host: "https://ace.example.com/aec/api.php", Account: "configured", ApiKey: "configured-apikey"},
+		},
+		"environment": {
+			env:      map[string]string{"ACE_HOST": "http://localhost:8080/", "ACE_APIKEY": "environment-apikey"},
+			expected: beamCredentials{Host: "http://localhost:8080/aec/api.php", Account: "shop", ApiKey: "environment-apikey"},
+		},
+		"named profile token": {
+			env:      map[string]string{"ACE_PROFILE": "token"},
+			expected: beamCredentials{Host: "https://token.example.com/aec/api.php", Token: "pre-issued=token"},
+		},
+		"credential process before profile": {
+			attributes: map[string]tftypes.Value{"profile": tftypes.NewValue(tftypes.String, "process")},
+			expected:   beamCredentials{Host: "https://preferred.example.com/aec/api.php", Account: "processed", ApiKey: "process-apikey"},
+		},
+		"failed credential process": {
+			attributes: map[string]tftypes.Value{
+				"profile":            tftypes.NewValue(tftypes.String, "production"),
+				"credential_process": tftypes.NewValue(tftypes.String, "exit 3"),
+			},
+			error: "Failed Amenesik Credential Process",
+		},
+		"missing profile": {
+			attributes: map[string]tftypes.Value{"profile": tftypes.NewValue(tftypes.String, "staging")},
+			error:      "Invalid Amenesik Credentials Profile",
+		},
+		"missing apikey": {
+			env:   map[string]string{"ACE_CREDENTIALS_FILE": filepath.Join(t.TempDir(), "missing"), "ACE_HOST": "ace.example.com", "ACE_ACCOUNT": "shop"},
+			error: "Missing Amenesik API KEY",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"ACE_HOST", "ACE_ACCOUNT", "ACE_APIKEY", "ACE_TOKEN", "ACE_PROFILE", "ACE_TOKEN_CACHE", "ACE_RECORD", "ACE_REPLAY"} {
+				t.Setenv(key, "")
+			}
+			t.Setenv("ACE_CREDENTIALS_FILE", file)
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			c, summary := testProviderConfigure(t, test.attributes)
+			if summary != test.error {
+				t.Fatalf("expected the error %q, got %q", test.error, summary)
+			}
+			if c == nil {
+				return
+			}
+			if got := (beamCredentials{Host: c.baseURL, Account: c.account, ApiKey: c.apikey, Token: c.token}); got != test.expected {
+				t.Errorf("expected the client credentials %+v, got %+v", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &metricsDataSource{}
+    _ datasource.DataSourceWithConfigure = &metricsDataSource{}
+)
+
+// NewMetricsDataSource is a helper function to simplify the provider implementation.
+func NewMetricsDataSource() datasource.DataSource {
+    return &metricsDataSource{}
+}
+
+// metrics Data Source is the data source implementation.
+type metricsDataSource struct{
+	client *Client
+}
+
+// metricsDataSourceModel maps the data source schema data.
+type metricsDataSourceModel struct {
+    Names       []types.String   `tfsdk:"names"`
+    Metrics     []metricModel    `tfsdk:"metrics"`
+}
+
+// the account monitoring metric model
+type metricModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Description types.String	`tfsdk:"description"`
+    Frequency   types.String	`tfsdk:"frequency"`
+    Collector   types.String	`tfsdk:"collector"`
+}
+
+func (d *metricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_metrics"
+}
+
+// Schema defines the schema for the data source.
+func (d *metricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the monitoring metrics available to the probes of the account.",
+        Attributes: map[string]schema.Attribute{
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "metrics": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The metric name, such as memory:free, used by the probe metric property.",
+                        },
+                        "description": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "frequency": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The collection frequency of the metric.",
+                        },
+                        "collector": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The means of collection of the metric.",
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *metricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state metricsDataSourceModel
+    tflog.Info(ctx,"AMENESIK:METRICS ENTER:READ");
+
+    metrics, err := d.client.ListBeamMetrics(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik Metrics",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.Names = []types.String{}
+    state.Metrics = []metricModel{}
+    for _, m := range metrics {
+        state.Names = append(state.Names, types.StringValue(m.Name))
+        state.Metrics = append(state.Metrics, metricModel{
+            Name:        types.StringValue(m.Name),
+            Description: types.StringValue(m.Description),
+            Frequency:   types.StringValue(m.Frequency),
+            Collector:   types.StringValue(m.Collector),
+        })
+    }
+
+    diags := resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:METRICS LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *metricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMetricsDataSource(t *testing.T) {
+	body := `{"status":"200","result":[
+		{"name":"memory:free","description":"The free memory","frequency":"60","collector":"agent"},
+		{"name":"load:average:1"}]}`
+	resp := testDataSourceRead(t, NewMetricsDataSource(), testListClient(t, "metric", http.StatusOK, body), nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state metricsDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := testStrings(state.Names); !reflect.DeepEqual(got, []string{"memory:free", "load:average:1"}) {
+		t.Errorf("unexpected names %v", got)
+	}
+	expected := []metricModel{
+		{Name: types.StringValue("memory:free"), Description: types.StringValue("The free memory"), Frequency: types.StringValue("60"), Collector: types.StringValue("agent")},
+		{Name: types.StringValue("load:average:1"), Description: types.StringValue(""), Frequency: types.StringValue(""), Collector: types.StringValue("")},
+	}
+	if !reflect.DeepEqual(state.Metrics, expected) {
+		t.Errorf("expected the metrics %v, got %v", expected, state.Metrics)
+	}
+}
+
+func TestMetricsDataSourceFailure(t *testing.T) {
+	for name, status := range map[string]int{"unauthorized": http.StatusUnauthorized, "server": http.StatusBadGateway} {
+		t.Run(name, func(t *testing.T) {
+			resp := testDataSourceRead(t, NewMetricsDataSource(), testListClient(t, "metric", status, "failure"), nil)
+			if got := testDataSourceError(resp); got != "Unable to List Amenesik Metrics" {
+				t.Errorf("unexpected error %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ resource.Resource                = &nodeTypeResource{}
+    _ resource.ResourceWithConfigure   = &nodeTypeResource{}
+    _ resource.ResourceWithImportState = &nodeTypeResource{}
+)
+
+// NewNodeTypeResource is a helper function to simplify the provider implementation.
+func NewNodeTypeResource() resource.Resource {
+    return &nodeTypeResource{}
+}
+
+// node type Resource is the resource implementation.
+type nodeTypeResource struct{
+	client *Client
+}
+
+// nodeTypeResourceModel maps the resource schema data.
+type nodeTypeResourceModel struct {
+    ID          types.String     `tfsdk:"id"`
+    Name        types.String     `tfsdk:"name"`
+    Create      types.String     `tfsdk:"create"`
+    Start       types.String     `tfsdk:"start"`
+    Stop        types.String     `tfsdk:"stop"`
+    Save        types.String     `tfsdk:"save"`
+    Delete      types.String     `tfsdk:"delete"`
+    TcpPorts    []types.String   `tfsdk:"tcp_ports"`
+    UdpPorts    []types.String   `tfsdk:"udp_ports"`
+    LastUpdated types.String     `tfsdk:"last_updated"`
+}
+
+func (r *nodeTypeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_node_type"
+}
+
+// Schema defines the schema for the resource.
+func (r *nodeTypeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Registers a reusable TOSCA node type at account level, which BEAM documents may import by name.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Computed: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "last_updated": schema.StringAttribute{
+                Computed: true,
+            },
+            "name": schema.StringAttribute{
+                Required: true,
+                Description: "The name of the node type, used by BEAM imports and node types.",
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "create": schema.StringAttribute{
+                Optional: true,
+                Description: "The URL of the life cycle script performing the create operation.",
+            },
+            "start": schema.StringAttribute{
+                Optional: true,
+                Description: "The URL of the life cycle script performing the start operation.",
+            },
+            "stop": schema.StringAttribute{
+                Optional: true,
+                Description: "The URL of the life cycle script performing the stop operation.",
+            },
+            "save": schema.StringAttribute{
+                Optional: true,
+                Description: "The URL of the life cycle script performing the save operation.",
+            },
+            "delete": schema.StringAttribute{
+                Optional: true,
+                Description: "The URL of the life cycle script performing the delete operation.",
+            },
+            "tcp_ports": schema.ListAttribute{
+                Optional: true,
+                ElementType: types.StringType,
+                Description: "The TCP ports or dash separated port ranges to be opened for nodes of this type.",
+            },
+            "udp_ports": schema.ListAttribute{
+                Optional: true,
+                ElementType: types.StringType,
+                Description: "The UDP ports or dash separated port ranges to be opened for nodes of this type.",
+            },
+        },
+    }
+}
+
+// returns the client node type definition described by the model
+func (m *nodeTypeResourceModel) definition() BeamNodeTypeDefinition {
+    t := BeamNodeTypeDefinition{
+        Name:   m.Name.ValueString(),
+        Create: m.Create.ValueString(),
+        Start:  m.Start.ValueString(),
+        Stop:   m.Stop.ValueString(),
+        Save:   m.Save.ValueString(),
+        Delete: m.Delete.ValueString(),
+    }
+    for _, p := range m.TcpPorts {
+        t.TcpPorts = append(t.TcpPorts, p.ValueString())
+    }
+    for _, p := range m.UdpPorts {
+        t.UdpPorts = append(t.UdpPorts, p.ValueString())
+    }
+    return t
+}
+
+// returns the value of an optional attribute, empty values remaining null when not configured
+func optionalString(v string, prior types.String) types.String {
+    if v == "" && prior.IsNull() {
+        return types.StringNull()
+    }
+    return types.StringValue(v)
+}
+
+// returns the values of an optional list, an empty list remaining null when not configured
+func optionalStrings(values []string, prior []types.String) []types.String {
+    if len(values) == 0 && prior == nil {
+        return nil
+    }
+    return stringModels(values)
+}
+
+// sets the model from the node type definition returned by ACE
+func (m *nodeTypeResourceModel) setDefinition(t *BeamNodeTypeDefinition) {
+    m.ID = types.StringValue(t.Name)
+    m.Name = types.StringValue(t.Name)
+    m.Create = optionalString(t.Create, m.Create)
+    m.Start = optionalString(t.Start, m.Start)
+    m.Stop = optionalString(t.Stop, m.Stop)
+    m.Save = optionalString(t.Save, m.Save)
+    m.Delete = optionalString(t.Delete, m.Delete)
+    m.TcpPorts = optionalStrings(t.TcpPorts, m.TcpPorts)
+    m.UdpPorts = optionalStrings(t.UdpPorts, m.UdpPorts)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *nodeTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan nodeTypeResourceModel
+    tflog.Info(ctx,"AMENESIK:NODE TYPE ENTER:CREATE: Get Plan");
+    diags := req.Plan.Get(ctx, &plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if err := r.client.CreateBeamNodeType(ctx, plan.definition()); err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Create Amenesik Node Type",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    plan.ID = plan.Name
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:NODE TYPE LEAVE:CREATE: SUCCESS");
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *nodeTypeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state nodeTypeResourceModel
+    tflog.Info(ctx,"AMENESIK:NODE TYPE ENTER:READ: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    t, err := r.client.GetBeamNodeType(ctx, state.ID.ValueString())
+    if errors.Is(err, ErrBeamNotFound) {
+        tflog.Info(ctx,"AMENESIK:NODE TYPE LEAVE:READ: NOT FOUND");
+        resp.State.RemoveResource(ctx)
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik Node Type",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.setDefinition(t)
+    diags = resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:NODE TYPE LEAVE:READ: SUCCESS");
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *nodeTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan nodeTypeResourceModel
+    tflog.Info(ctx,"AMENESIK:NODE TYPE ENTER:UPDATE: Get Plan");
+    diags := req.Plan.Get(ctx, &plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if err := r.client.UpdateBeamNodeType(ctx, plan.definition()); err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Update Amenesik Node Type",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    plan.ID = plan.Name
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:NODE TYPE LEAVE:UPDATE: SUCCESS");
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *nodeTypeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state nodeTypeResourceModel
+    tflog.Info(ctx,"AMENESIK:NODE TYPE ENTER:DELETE: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    err := r.client.DeleteBeamNodeType(ctx, state.ID.ValueString())
+    if err != nil && !errors.Is(err, ErrBeamNotFound) {
+        resp.Diagnostics.AddError(
+            "Unable to Delete Amenesik Node Type",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+    tflog.Info(ctx,"AMENESIK:NODE TYPE LEAVE:DELETE: SUCCESS");
+}
+
+// ImportState imports an existing node type by name.
+func (r *nodeTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *nodeTypeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    r.client = client
+}
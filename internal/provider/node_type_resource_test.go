@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRegistry holds the account level definitions of a subject, as
+// registered by the requests of its client.
+type testRegistry struct {
+	mu          sync.Mutex
+	definitions map[string]map[string]string
+	failure     string
+}
+
+// get returns the fields of the named definition.
+func (r *testRegistry) get(name string) (map[string]string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fields, ok := r.definitions[name]
+	return fields, ok
+}
+
+// set registers the fields of the named definition.
+func (r *testRegistry) set(name string, fields map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fields["name"] = name
+	r.definitions[name] = fields
+}
+
+// fail makes the requests of the action fail.
+func (r *testRegistry) fail(action string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failure = action
+}
+
+// testRegistryClient returns a client of a server registering the definitions
+// of the subject, the fields of the lists being comma separated in requests.
+func testRegistryClient(t *testing.T, subject string, lists ...string) (*Client, *testRegistry) {
+	registry := &testRegistry{definitions: map[string]map[string]string{}}
+	c := testClient(t, func(req map[string]string) (int, string) {
+		if req["subject"] != subject {
+			return http.StatusBadRequest, "unexpected request"
+		}
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+		action, name := req["action"], req["name"]
+		if action == registry.failure {
+			return http.StatusOK, `{"status":"500","message":"failure"}`
+		}
+		fields, ok := registry.definitions[name]
+		if !ok && action != "create" {
+			return http.StatusOK, `{"status":"404","message":"not found"}`
+		}
+		switch action {
+		case "create", "update":
+			if ok && action == "create" {
+				return http.StatusOK, `{"status":"409","message":"exists"}`
+			}
+			fields = map[string]string{}
+			for k, v := range req {
+				if k != "action" && k != "subject" && k != "account" && k != "auth" {
+					fields[k] = v
+				}
+			}
+			registry.definitions[name] = fields
+		case "get":
+			result := map[string]interface{}{}
+			for k, v := range fields {
+				result[k] = v
+			}
+			for _, k := range lists {
+				delete(result, k)
+				if fields[k] != "" {
+					result[k] = strings.Split(fields[k], ",")
+				}
+			}
+			body, _ := json.Marshal(map[string]interface{}{"status": "200", "result": result})
+			return http.StatusOK, string(body)
+		case "delete":
+			delete(registry.definitions, name)
+		default:
+			return http.StatusBadRequest, "unexpected request"
+		}
+		return http.StatusOK, `{"status":"200"}`
+	})
+	return c, registry
+}
+
+// testStringList returns the list value of the strings, or null without strings.
+func testStringList(values []string) tftypes.Value {
+	listType := tftypes.List{ElementType: tftypes.String}
+	if values == nil {
+		return tftypes.NewValue(listType, nil)
+	}
+	items := []tftypes.Value{}
+	for _, v := range values {
+		items = append(items, tftypes.NewValue(tftypes.String, v))
+	}
+	return tftypes.NewValue(listType, items)
+}
+
+// testNodeTypeModel returns the node type model of the state.
+func testNodeTypeModel(t *testing.T, state tfsdk.State) nodeTypeResourceModel {
+	t.Helper()
+	var m nodeTypeResourceModel
+	if diags := state.Get(context.Background(), &m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return m
+}
+
+func TestNodeTypeResource(t *testing.T) {
+	ctx := context.Background()
+	c, registry := testRegistryClient(t, "type", "tcp_port", "udp_port")
+	r := testResourceConfigure(t, NewNodeTypeResource(), c)
+
+	// Create testing, the unset scripts and ports remaining null
+	state := testResourceCreate(t, r, map[string]tftypes.Value{
+		"name":      tftypes.NewValue(tftypes.String, "nginx"),
+		"create":    tftypes.NewValue(tftypes.String, "nginx-create.sh"),
+		"start":     tftypes.NewValue(tftypes.String, "nginx-start.sh"),
+		"tcp_ports": testStringList([]string{"80", "443"}),
+	})
+	fields, ok := registry.get("nginx")
+	if !ok {
+		t.Fatal("expected the nginx node type to be registered")
+	}
+	if fields["create"] != "nginx-create.sh" || fields["start"] != "nginx-start.sh" || fields["stop"] != "" || fields["tcp_port"] != "80,443" || fields["udp_port"] != "" {
+		t.Errorf("unexpected registered node type %v", fields)
+	}
+	m := testNodeTypeModel(t, state)
+	if m.ID.ValueString() != "nginx" || m.LastUpdated.ValueString() == "" {
+		t.Errorf("unexpected id %q and last update %q", m.ID.ValueString(), m.LastUpdated.ValueString())
+	}
+	if !m.Stop.IsNull() || !m.Save.IsNull() || !m.Delete.IsNull() || m.UdpPorts != nil {
+		t.Errorf("expected null optional attributes, got %v", m)
+	}
+
+	// Read testing, a script set outside of terraform being refreshed
+	registry.set("nginx", map[string]string{"create": "nginx-create.sh", "start": "nginx-start.sh", "stop": "nginx-stop.sh", "tcp_port": "80,443"})
+	state = testResourceRead(t, r, state)
+	m = testNodeTypeModel(t, state)
+	if m.Stop.ValueString() != "nginx-stop.sh" || m.Start.ValueString() != "nginx-start.sh" || !m.Save.IsNull() {
+		t.Errorf("unexpected scripts %v", m)
+	}
+	if got := testStrings(m.TcpPorts); !reflect.DeepEqual(got, []string{"80", "443"}) || m.UdpPorts != nil {
+		t.Errorf("unexpected ports %v %v", got, m.UdpPorts)
+	}
+
+	// Update testing, the scripts no longer configured being removed
+	attributes := map[string]tftypes.Value{
+		"id":        tftypes.NewValue(tftypes.String, "nginx"),
+		"name":      tftypes.NewValue(tftypes.String, "nginx"),
+		"create":    tftypes.NewValue(tftypes.String, "nginx-create.sh"),
+		"start":     tftypes.NewValue(tftypes.String, "nginx-run.sh"),
+		"tcp_ports": testStringList([]string{"8080"}),
+		"udp_ports": testStringList([]string{"5000-5010"}),
+	}
+	update := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: testResourcePlan(r, attributes), Config: testResourceConfig(r, attributes), State: state}, &update)
+	if update.Diagnostics.HasError() {
+		t.Fatal(update.Diagnostics)
+	}
+	fields, _ = registry.get("nginx")
+	if fields["start"] != "nginx-run.sh" || fields["stop"] != "" || fields["tcp_port"] != "8080" || fields["udp_port"] != "5000-5010" {
+		t.Errorf("unexpected updated node type %v", fields)
+	}
+	state = update.State
+	if m = testNodeTypeModel(t, state); m.Start.ValueString() != "nginx-run.sh" || !m.Stop.IsNull() {
+		t.Errorf("unexpected updated state %v", m)
+	}
+
+	// Delete testing, the node type being removed from the state once deleted
+	del := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &del)
+	if del.Diagnostics.HasError() {
+		t.Fatal(del.Diagnostics)
+	}
+	if _, ok := registry.get("nginx"); ok {
+		t.Error("expected the nginx node type to be deleted")
+	}
+	del = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &del)
+	if del.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics deleting a deleted node type %v", del.Diagnostics)
+	}
+	if state = testResourceRead(t, r, state); !state.Raw.IsNull() {
+		t.Errorf("expected the deleted node type to be removed, got %v", state.Raw)
+	}
+}
+
+func TestNodeTypeResourcePorts(t *testing.T) {
+	tests := map[string][]string{
+		"null":  nil,
+		"empty": {},
+		"ports": {"80", "443"},
+	}
+	for name, ports := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := testRegistryClient(t, "type", "tcp_port", "udp_port")
+			r := testResourceConfigure(t, NewNodeTypeResource(), c)
+			state := testResourceCreate(t, r, map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, "nginx"),
+				"tcp_ports": testStringList(ports),
+			})
+			state = testResourceRead(t, r, state)
+			var got types.List
+			if diags := state.GetAttribute(context.Background(), path.Root("tcp_ports"), &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got.IsNull() != (ports == nil) || len(got.Elements()) != len(ports) {
+				t.Errorf("expected the TCP ports %#v, got %v", ports, got)
+			}
+		})
+	}
+}
+
+func TestNodeTypeResourceImport(t *testing.T) {
+	c, registry := testRegistryClient(t, "type", "tcp_port", "udp_port")
+	registry.set("nginx", map[string]string{"create": "nginx-create.sh", "tcp_port": "80"})
+	r := testResourceConfigure(t, NewNodeTypeResource(), c)
+
+	resp := resource.ImportStateResponse{State: testResourceNullState(r)}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: "nginx"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	m := testNodeTypeModel(t, testResourceRead(t, r, resp.State))
+	if m.ID.ValueString() != "nginx" || m.Name.ValueString() != "nginx" || m.Create.ValueString() != "nginx-create.sh" {
+		t.Errorf("unexpected imported node type %v", m)
+	}
+	if !m.Start.IsNull() || m.UdpPorts != nil {
+		t.Errorf("expected null unset attributes, got %v", m)
+	}
+	if got := testStrings(m.TcpPorts); !reflect.DeepEqual(got, []string{"80"}) {
+		t.Errorf("unexpected TCP ports %v", got)
+	}
+}
+
+func TestNodeTypeResourceFailure(t *testing.T) {
+	ctx := context.Background()
+	attributes := map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "nginx"),
+		"name": tftypes.NewValue(tftypes.String, "nginx"),
+	}
+	tests := map[string]string{
+		"create": "Unable to Create Amenesik Node Type",
+		"get":    "Unable to Read Amenesik Node Type",
+		"update": "Unable to Update Amenesik Node Type",
+		"delete": "Unable to Delete Amenesik Node Type",
+	}
+	for action, expected := range tests {
+		t.Run(action, func(t *testing.T) {
+			c, registry := testRegistryClient(t, "type", "tcp_port", "udp_port")
+			registry.set("nginx", map[string]string{})
+			registry.fail(action)
+			r := testResourceConfigure(t, NewNodeTypeResource(), c)
+			state := testResourceState(r, attributes)
+			var err string
+			switch action {
+			case "create":
+				resp := resource.CreateResponse{State: testResourceNullState(r)}
+				r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(r, attributes)}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "get":
+				resp := resource.ReadResponse{State: state}
+				r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "update":
+				resp := resource.UpdateResponse{State: state}
+				r.Update(ctx, resource.UpdateRequest{Plan: testResourcePlan(r, attributes), State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "delete":
+				resp := resource.DeleteResponse{State: state}
+				r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			}
+			if err != expected {
+				t.Errorf("expected the error %q, got %q", expected, err)
+			}
+		})
+	}
+}
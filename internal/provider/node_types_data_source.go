@@ -0,0 +1,193 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &nodeTypesDataSource{}
+    _ datasource.DataSourceWithConfigure = &nodeTypesDataSource{}
+)
+
+// NewNodeTypesDataSource is a helper function to simplify the provider implementation.
+func NewNodeTypesDataSource() datasource.DataSource {
+    return &nodeTypesDataSource{}
+}
+
+// node types Data Source is the data source implementation.
+type nodeTypesDataSource struct{
+	client *Client
+}
+
+// nodeTypesDataSourceModel maps the data source schema data.
+type nodeTypesDataSourceModel struct {
+    Names       []types.String   `tfsdk:"names"`
+    NodeTypes   []nodeTypeModel  `tfsdk:"node_types"`
+}
+
+// the importable node type model
+type nodeTypeModel struct {
+    Name         types.String              `tfsdk:"name"`
+    Create       types.String              `tfsdk:"create"`
+    Start        types.String              `tfsdk:"start"`
+    Stop         types.String              `tfsdk:"stop"`
+    Save         types.String              `tfsdk:"save"`
+    Delete       types.String              `tfsdk:"delete"`
+    TcpPorts     []types.String            `tfsdk:"tcp_ports"`
+    UdpPorts     []types.String            `tfsdk:"udp_ports"`
+    Capabilities []nodeTypeCapabilityModel `tfsdk:"capabilities"`
+}
+
+// the node type capability model
+type nodeTypeCapabilityModel struct {
+    Name         types.String   `tfsdk:"name"`
+    Properties   []types.String `tfsdk:"properties"`
+}
+
+func (d *nodeTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_node_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *nodeTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the TOSCA node types the account may import or use as BEAM software node types.",
+        Attributes: map[string]schema.Attribute{
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "node_types": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "create": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The life cycle script performing the create operation.",
+                        },
+                        "start": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "stop": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "save": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "delete": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "tcp_ports": schema.ListAttribute{
+                            Computed: true,
+                            ElementType: types.StringType,
+                            Description: "The TCP ports or port ranges declared by the node type.",
+                        },
+                        "udp_ports": schema.ListAttribute{
+                            Computed: true,
+                            ElementType: types.StringType,
+                            Description: "The UDP ports or port ranges declared by the node type.",
+                        },
+                        "capabilities": schema.ListNestedAttribute{
+                            Computed: true,
+                            NestedObject: schema.NestedAttributeObject{
+                                Attributes: map[string]schema.Attribute{
+                                    "name": schema.StringAttribute{
+                                        Computed: true,
+                                    },
+                                    "properties": schema.ListAttribute{
+                                        Computed: true,
+                                        ElementType: types.StringType,
+                                        Description: "The names of the properties of the capability.",
+                                    },
+                                },
+                            },
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// returns the string list model of a collection of strings
+func stringModels(values []string) []types.String {
+    items := []types.String{}
+    for _, v := range values {
+        items = append(items, types.StringValue(v))
+    }
+    return items
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *nodeTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var state nodeTypesDataSourceModel
+    tflog.Info(ctx,"AMENESIK:NODE TYPES ENTER:READ");
+
+    nodeTypes, err := d.client.ListBeamNodeTypes(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik Node Types",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.Names = []types.String{}
+    state.NodeTypes = []nodeTypeModel{}
+    for _, t := range nodeTypes {
+        item := nodeTypeModel{
+            Name:         types.StringValue(t.Name),
+            Create:       types.StringValue(t.Create),
+            Start:        types.StringValue(t.Start),
+            Stop:         types.StringValue(t.Stop),
+            Save:         types.StringValue(t.Save),
+            Delete:       types.StringValue(t.Delete),
+            TcpPorts:     stringModels(t.TcpPorts),
+            UdpPorts:     stringModels(t.UdpPorts),
+            Capabilities: []nodeTypeCapabilityModel{},
+        }
+        for _, c := range t.Capabilities {
+            item.Capabilities = append(item.Capabilities, nodeTypeCapabilityModel{
+                Name:       types.StringValue(c.Name),
+                Properties: stringModels(c.Properties),
+            })
+        }
+        state.Names = append(state.Names, item.Name)
+        state.NodeTypes = append(state.NodeTypes, item)
+    }
+
+    diags := resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:NODE TYPES LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *nodeTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// the node types of the account of the node type tests
+const testNodeTypes = `{"status":"200","result":[
+	{"name":"nginx","create":"nginx-create.sh","start":"nginx-start.sh","stop":"nginx-stop.sh",
+	 "tcp_port":["80","443"],"udp_port":["5000-5010"],
+	 "capabilities":[{"name":"web","properties":["port","root"]},{"name":"status"}]},
+	{"name":"mysql","create":"mysql-create.sh","save":"mysql-save.sh","delete":"mysql-delete.sh","tcp_port":["3306"]}]}`
+
+func TestNodeTypesDataSource(t *testing.T) {
+	resp := testDataSourceRead(t, NewNodeTypesDataSource(), testListClient(t, "type", http.StatusOK, testNodeTypes), nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var state nodeTypesDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := testStrings(state.Names); !reflect.DeepEqual(got, []string{"nginx", "mysql"}) {
+		t.Errorf("unexpected names %v", got)
+	}
+	if len(state.NodeTypes) != 2 {
+		t.Fatalf("expected 2 node types, got %d", len(state.NodeTypes))
+	}
+
+	nginx := state.NodeTypes[0]
+	scripts := []string{nginx.Create.ValueString(), nginx.Start.ValueString(), nginx.Stop.ValueString(), nginx.Save.ValueString(), nginx.Delete.ValueString()}
+	if !reflect.DeepEqual(scripts, []string{"nginx-create.sh", "nginx-start.sh", "nginx-stop.sh", "", ""}) {
+		t.Errorf("unexpected scripts %v", scripts)
+	}
+	if got := testStrings(nginx.TcpPorts); !reflect.DeepEqual(got, []string{"80", "443"}) {
+		t.Errorf("unexpected TCP ports %v", got)
+	}
+	if got := testStrings(nginx.UdpPorts); !reflect.DeepEqual(got, []string{"5000-5010"}) {
+		t.Errorf("unexpected UDP ports %v", got)
+	}
+	if len(nginx.Capabilities) != 2 {
+		t.Fatalf("expected 2 capabilities, got %v", nginx.Capabilities)
+	}
+	if c := nginx.Capabilities[0]; c.Name.ValueString() != "web" || !reflect.DeepEqual(testStrings(c.Properties), []string{"port", "root"}) {
+		t.Errorf("unexpected capability %v", c)
+	}
+	if c := nginx.Capabilities[1]; c.Name.ValueString() != "status" || c.Properties == nil || len(c.Properties) != 0 {
+		t.Errorf("expected a capability without properties, got %v", c)
+	}
+
+	mysql := state.NodeTypes[1]
+	if mysql.UdpPorts == nil || len(mysql.UdpPorts) != 0 {
+		t.Errorf("expected an empty list of UDP ports, got %v", mysql.UdpPorts)
+	}
+	if mysql.Capabilities == nil || len(mysql.Capabilities) != 0 {
+		t.Errorf("expected an empty list of capabilities, got %v", mysql.Capabilities)
+	}
+}
+
+func TestNodeTypesDataSourceEmpty(t *testing.T) {
+	resp := testDataSourceRead(t, NewNodeTypesDataSource(), testListClient(t, "type", http.StatusOK, `{"status":"200","result":[]}`), nil)
+	var state nodeTypesDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state.Names == nil || len(state.Names) != 0 || state.NodeTypes == nil || len(state.NodeTypes) != 0 {
+		t.Errorf("expected empty lists, got %v %v", state.Names, state.NodeTypes)
+	}
+}
+
+func TestNodeTypesDataSourceFailure(t *testing.T) {
+	resp := testDataSourceRead(t, NewNodeTypesDataSource(), testListClient(t, "type", http.StatusOK, `{"status":"500","message":"failure"}`), nil)
+	if got := testDataSourceError(resp); got != "Unable to List Amenesik Node Types" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
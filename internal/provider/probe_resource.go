@@ -0,0 +1,251 @@
+package provider
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "time"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/resource"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ resource.Resource                = &probeResource{}
+    _ resource.ResourceWithConfigure   = &probeResource{}
+    _ resource.ResourceWithImportState = &probeResource{}
+)
+
+// NewProbeResource is a helper function to simplify the provider implementation.
+func NewProbeResource() resource.Resource {
+    return &probeResource{}
+}
+
+// probe Resource is the resource implementation.
+type probeResource struct{
+	client *Client
+}
+
+// probeResourceModel maps the resource schema data.
+type probeResourceModel struct {
+    ID          types.String     `tfsdk:"id"`
+    Name        types.String     `tfsdk:"name"`
+    Metric      types.String     `tfsdk:"metric"`
+    Condition   types.String     `tfsdk:"condition"`
+    Threshold   types.String     `tfsdk:"threshold"`
+    Type        types.String     `tfsdk:"type"`
+    Nature      types.String     `tfsdk:"nature"`
+    Behaviour   types.String     `tfsdk:"behaviour"`
+    LastUpdated types.String     `tfsdk:"last_updated"`
+}
+
+func (r *probeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_probe"
+}
+
+// Schema defines the schema for the resource.
+func (r *probeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Manages a monitoring probe definition at account level, which BEAM Probe tags may name.",
+        Attributes: map[string]schema.Attribute{
+            "id": schema.StringAttribute{
+                Computed: true,
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.UseStateForUnknown(),
+                },
+            },
+            "last_updated": schema.StringAttribute{
+                Computed: true,
+            },
+            "name": schema.StringAttribute{
+                Required: true,
+                Description: "The name of the probe, used by the tag.Probe values of BEAM documents.",
+                PlanModifiers: []planmodifier.String{
+                    stringplanmodifier.RequiresReplace(),
+                },
+            },
+            "metric": schema.StringAttribute{
+                Required: true,
+                Description: "The metric collected by the probe, such as memory:free.",
+            },
+            "condition": schema.StringAttribute{
+                Optional: true,
+                Description: "The comparison with the threshold: eq, gr, ls, ge, le or ne.",
+            },
+            "threshold": schema.StringAttribute{
+                Optional: true,
+                Description: "The threshold value engaging the remediation action.",
+            },
+            "type": schema.StringAttribute{
+                Optional: true,
+                Description: "The action invocation type: OCCISCRIPT, BASH or PYTHON.",
+            },
+            "nature": schema.StringAttribute{
+                Optional: true,
+                Description: "The nature of the action: penalty, reward or both.",
+            },
+            "behaviour": schema.StringAttribute{
+                Optional: true,
+                Description: "The name of the script describing the action.",
+            },
+        },
+    }
+}
+
+// returns the client probe definition described by the model
+func (m *probeResourceModel) definition() BeamProbeDefinition {
+    return BeamProbeDefinition{
+        Name:      m.Name.ValueString(),
+        Metric:    m.Metric.ValueString(),
+        Condition: m.Condition.ValueString(),
+        Threshold: m.Threshold.ValueString(),
+        Type:      m.Type.ValueString(),
+        Nature:    m.Nature.ValueString(),
+        Behaviour: m.Behaviour.ValueString(),
+    }
+}
+
+// sets the model from the probe definition returned by ACE
+func (m *probeResourceModel) setDefinition(p *BeamProbeDefinition) {
+    m.ID = types.StringValue(p.Name)
+    m.Name = types.StringValue(p.Name)
+    m.Metric = types.StringValue(p.Metric)
+    m.Condition = optionalString(p.Condition, m.Condition)
+    m.Threshold = optionalString(p.Threshold, m.Threshold)
+    m.Type = optionalString(p.Type, m.Type)
+    m.Nature = optionalString(p.Nature, m.Nature)
+    m.Behaviour = optionalString(p.Behaviour, m.Behaviour)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *probeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+    var plan probeResourceModel
+    tflog.Info(ctx,"AMENESIK:PROBE ENTER:CREATE: Get Plan");
+    diags := req.Plan.Get(ctx, &plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if err := r.client.CreateBeamProbe(ctx, plan.definition()); err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Create Amenesik Probe",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    plan.ID = plan.Name
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:PROBE LEAVE:CREATE: SUCCESS");
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *probeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+    var state probeResourceModel
+    tflog.Info(ctx,"AMENESIK:PROBE ENTER:READ: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    p, err := r.client.GetBeamProbe(ctx, state.ID.ValueString())
+    if errors.Is(err, ErrBeamNotFound) {
+        tflog.Info(ctx,"AMENESIK:PROBE LEAVE:READ: NOT FOUND");
+        resp.State.RemoveResource(ctx)
+        return
+    }
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Read Amenesik Probe",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    state.setDefinition(p)
+    diags = resp.State.Set(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:PROBE LEAVE:READ: SUCCESS");
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *probeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+    var plan probeResourceModel
+    tflog.Info(ctx,"AMENESIK:PROBE ENTER:UPDATE: Get Plan");
+    diags := req.Plan.Get(ctx, &plan)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    if err := r.client.UpdateBeamProbe(ctx, plan.definition()); err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Update Amenesik Probe",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    plan.ID = plan.Name
+    plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+    diags = resp.State.Set(ctx, plan)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:PROBE LEAVE:UPDATE: SUCCESS");
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *probeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+    var state probeResourceModel
+    tflog.Info(ctx,"AMENESIK:PROBE ENTER:DELETE: Get State");
+    diags := req.State.Get(ctx, &state)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    err := r.client.DeleteBeamProbe(ctx, state.ID.ValueString())
+    if err != nil && !errors.Is(err, ErrBeamNotFound) {
+        resp.Diagnostics.AddError(
+            "Unable to Delete Amenesik Probe",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+    tflog.Info(ctx,"AMENESIK:PROBE LEAVE:DELETE: SUCCESS");
+}
+
+// ImportState imports an existing probe by name.
+func (r *probeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+    resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *probeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    r.client = client
+}
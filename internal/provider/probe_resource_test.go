@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProbeModel returns the probe model of the state.
+func testProbeModel(t *testing.T, state tfsdk.State) probeResourceModel {
+	t.Helper()
+	var m probeResourceModel
+	if diags := state.Get(context.Background(), &m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	return m
+}
+
+func TestProbeResource(t *testing.T) {
+	ctx := context.Background()
+	c, registry := testRegistryClient(t, "probe")
+	r := testResourceConfigure(t, NewProbeResource(), c)
+
+	// Create testing, the unset properties remaining null
+	state := testResourceCreate(t, r, map[string]tftypes.Value{
+		"name":      tftypes.NewValue(tftypes.String, "memory"),
+		"metric":    tftypes.NewValue(tftypes.String, "memory:free"),
+		"condition": tftypes.NewValue(tftypes.String, "ls"),
+		"threshold": tftypes.NewValue(tftypes.String, "100"),
+	})
+	fields, ok := registry.get("memory")
+	if !ok {
+		t.Fatal("expected the memory probe to be registered")
+	}
+	if fields["metric"] != "memory:free" || fields["condition"] != "ls" || fields["threshold"] != "100" || fields["nature"] != "" {
+		t.Errorf("unexpected registered probe %v", fields)
+	}
+	m := testProbeModel(t, state)
+	if m.ID.ValueString() != "memory" || m.LastUpdated.ValueString() == "" {
+		t.Errorf("unexpected id %q and last update %q", m.ID.ValueString(), m.LastUpdated.ValueString())
+	}
+	if !m.Type.IsNull() || !m.Nature.IsNull() || !m.Behaviour.IsNull() {
+		t.Errorf("expected null optional attributes, got %v", m)
+	}
+
+	// Read testing, a threshold changed outside of terraform being refreshed
+	registry.set("memory", map[string]string{"metric": "memory:free", "condition": "ls", "threshold": "200", "behaviour": "scale"})
+	state = testResourceRead(t, r, state)
+	m = testProbeModel(t, state)
+	if m.Threshold.ValueString() != "200" || m.Behaviour.ValueString() != "scale" || m.Condition.ValueString() != "ls" || !m.Nature.IsNull() {
+		t.Errorf("unexpected refreshed probe %v", m)
+	}
+
+	// Update testing, the properties no longer configured being removed
+	attributes := map[string]tftypes.Value{
+		"id":        tftypes.NewValue(tftypes.String, "memory"),
+		"name":      tftypes.NewValue(tftypes.String, "memory"),
+		"metric":    tftypes.NewValue(tftypes.String, "memory:used"),
+		"condition": tftypes.NewValue(tftypes.String, "gr"),
+		"threshold": tftypes.NewValue(tftypes.String, "900"),
+		"nature":    tftypes.NewValue(tftypes.String, "penalty"),
+	}
+	update := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: testResourcePlan(r, attributes), Config: testResourceConfig(r, attributes), State: state}, &update)
+	if update.Diagnostics.HasError() {
+		t.Fatal(update.Diagnostics)
+	}
+	fields, _ = registry.get("memory")
+	if fields["metric"] != "memory:used" || fields["condition"] != "gr" || fields["threshold"] != "900" || fields["nature"] != "penalty" || fields["behaviour"] != "" {
+		t.Errorf("unexpected updated probe %v", fields)
+	}
+	state = update.State
+	if m = testProbeModel(t, state); m.Metric.ValueString() != "memory:used" || !m.Behaviour.IsNull() {
+		t.Errorf("unexpected updated state %v", m)
+	}
+
+	// Delete testing, the probe being removed from the state once deleted
+	del := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &del)
+	if del.Diagnostics.HasError() {
+		t.Fatal(del.Diagnostics)
+	}
+	if _, ok := registry.get("memory"); ok {
+		t.Error("expected the memory probe to be deleted")
+	}
+	del = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &del)
+	if del.Diagnostics.HasError() {
+		t.Errorf("unexpected diagnostics deleting a deleted probe %v", del.Diagnostics)
+	}
+	if state = testResourceRead(t, r, state); !state.Raw.IsNull() {
+		t.Errorf("expected the deleted probe to be removed, got %v", state.Raw)
+	}
+}
+
+func TestProbeResourceImport(t *testing.T) {
+	c, registry := testRegistryClient(t, "probe")
+	registry.set("disk", map[string]string{"metric": "disk:free", "condition": "ls", "threshold": "10"})
+	r := testResourceConfigure(t, NewProbeResource(), c)
+
+	resp := resource.ImportStateResponse{State: testResourceNullState(r)}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: "disk"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	m := testProbeModel(t, testResourceRead(t, r, resp.State))
+	if m.ID.ValueString() != "disk" || m.Name.ValueString() != "disk" || m.Metric.ValueString() != "disk:free" || m.Threshold.ValueString() != "10" {
+		t.Errorf("unexpected imported probe %v", m)
+	}
+	if !m.Type.IsNull() || !m.Nature.IsNull() || !m.Behaviour.IsNull() {
+		t.Errorf("expected null unset attributes, got %v", m)
+	}
+}
+
+func TestProbeResourceImportMissing(t *testing.T) {
+	c, _ := testRegistryClient(t, "probe")
+	r := testResourceConfigure(t, NewProbeResource(), c)
+
+	resp := resource.ImportStateResponse{State: testResourceNullState(r)}
+	r.(resource.ResourceWithImportState).ImportState(context.Background(), resource.ImportStateRequest{ID: "disk"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if state := testResourceRead(t, r, resp.State); !state.Raw.IsNull() {
+		t.Errorf("expected an unknown probe to be removed, got %v", state.Raw)
+	}
+}
+
+func TestProbeResourceFailure(t *testing.T) {
+	ctx := context.Background()
+	attributes := map[string]tftypes.Value{
+		"id":     tftypes.NewValue(tftypes.String, "memory"),
+		"name":   tftypes.NewValue(tftypes.String, "memory"),
+		"metric": tftypes.NewValue(tftypes.String, "memory:free"),
+	}
+	tests := map[string]string{
+		"create": "Unable to Create Amenesik Probe",
+		"get":    "Unable to Read Amenesik Probe",
+		"update": "Unable to Update Amenesik Probe",
+		"delete": "Unable to Delete Amenesik Probe",
+	}
+	for action, expected := range tests {
+		t.Run(action, func(t *testing.T) {
+			c, registry := testRegistryClient(t, "probe")
+			registry.set("memory", map[string]string{"metric": "memory:free"})
+			registry.fail(action)
+			r := testResourceConfigure(t, NewProbeResource(), c)
+			state := testResourceState(r, attributes)
+			var err string
+			switch action {
+			case "create":
+				resp := resource.CreateResponse{State: testResourceNullState(r)}
+				r.Create(ctx, resource.CreateRequest{Plan: testResourcePlan(r, attributes)}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "get":
+				resp := resource.ReadResponse{State: state}
+				r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "update":
+				resp := resource.UpdateResponse{State: state}
+				r.Update(ctx, resource.UpdateRequest{Plan: testResourcePlan(r, attributes), State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			case "delete":
+				resp := resource.DeleteResponse{State: state}
+				r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+				err = testResourceError(resp.Diagnostics)
+			}
+			if err != expected {
+				t.Errorf("expected the error %q, got %q", expected, err)
+			}
+		})
+	}
+}
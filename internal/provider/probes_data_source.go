@@ -0,0 +1,160 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &probesDataSource{}
+    _ datasource.DataSourceWithConfigure = &probesDataSource{}
+)
+
+// NewProbesDataSource is a helper function to simplify the provider implementation.
+func NewProbesDataSource() datasource.DataSource {
+    return &probesDataSource{}
+}
+
+// probes Data Source is the data source implementation.
+type probesDataSource struct{
+	client *Client
+}
+
+// probesDataSourceModel maps the data source schema data.
+type probesDataSourceModel struct {
+    Metric      types.String     `tfsdk:"metric"`
+    Names       []types.String   `tfsdk:"names"`
+    Probes      []probeModel     `tfsdk:"probes"`
+}
+
+// the account probe definition model
+type probeModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Metric      types.String	`tfsdk:"metric"`
+    Condition   types.String	`tfsdk:"condition"`
+    Threshold   types.String	`tfsdk:"threshold"`
+    Type        types.String	`tfsdk:"type"`
+    Nature      types.String	`tfsdk:"nature"`
+    Behaviour   types.String	`tfsdk:"behaviour"`
+}
+
+func (d *probesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_probes"
+}
+
+// Schema defines the schema for the data source.
+func (d *probesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the monitoring probe definitions available to the account.",
+        Attributes: map[string]schema.Attribute{
+            "metric": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list the probes collecting this metric.",
+            },
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "probes": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "metric": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "condition": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The comparison with the threshold: eq, gr, ls, ge, le or ne.",
+                        },
+                        "threshold": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "type": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The action invocation type: OCCISCRIPT, BASH or PYTHON.",
+                        },
+                        "nature": schema.StringAttribute{
+                            Computed: true,
+                            Description: "The nature of the action: penalty, reward or both.",
+                        },
+                        "behaviour": schema.StringAttribute{
+                            Computed: true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *probesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config probesDataSourceModel
+    tflog.Info(ctx,"AMENESIK:PROBES ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    probes, err := d.client.ListBeamProbes(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik Probes",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    config.Names = []types.String{}
+    config.Probes = []probeModel{}
+    for _, p := range probes {
+        if !config.Metric.IsNull() && p.Metric != config.Metric.ValueString() {
+            continue
+        }
+        config.Names = append(config.Names, types.StringValue(p.Name))
+        config.Probes = append(config.Probes, probeModel{
+            Name:      types.StringValue(p.Name),
+            Metric:    types.StringValue(p.Metric),
+            Condition: types.StringValue(p.Condition),
+            Threshold: types.StringValue(p.Threshold),
+            Type:      types.StringValue(p.Type),
+            Nature:    types.StringValue(p.Nature),
+            Behaviour: types.StringValue(p.Behaviour),
+        })
+    }
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:PROBES LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *probesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
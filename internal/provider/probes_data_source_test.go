@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// the probes of the account of the probe data source tests
+const testProbes = `{"status":"200","result":[
+	{"name":"memory","metric":"memory:free","condition":"ls","threshold":"100","type":"BASH","nature":"penalty","behaviour":"scale"},
+	{"name":"disk","metric":"disk:free","condition":"ls","threshold":"10"},
+	{"name":"swap","metric":"memory:free","condition":"le","threshold":"50"}]}`
+
+// testListClient returns a client of a server answering the list of the subject.
+func testListClient(t *testing.T, subject string, status int, body string) *Client {
+	return testClient(t, func(req map[string]string) (int, string) {
+		if req["action"] != "list" || req["subject"] != subject {
+			return http.StatusBadRequest, "unexpected request"
+		}
+		return status, body
+	})
+}
+
+func TestProbesDataSource(t *testing.T) {
+	tests := map[string]struct {
+		metric string
+		names  []string
+	}{
+		"all probes":      {names: []string{"memory", "disk", "swap"}},
+		"metric probes":   {metric: "memory:free", names: []string{"memory", "swap"}},
+		"no metric probe": {metric: "load:average:1", names: []string{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			metric := tftypes.NewValue(tftypes.String, nil)
+			if test.metric != "" {
+				metric = tftypes.NewValue(tftypes.String, test.metric)
+			}
+			resp := testDataSourceRead(t, NewProbesDataSource(), testListClient(t, "probe", http.StatusOK, testProbes), map[string]tftypes.Value{
+				"metric": metric,
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var state probesDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := testStrings(state.Names); !reflect.DeepEqual(got, test.names) {
+				t.Errorf("expected the names %v, got %v", test.names, got)
+			}
+			if len(state.Probes) != len(test.names) {
+				t.Errorf("expected %d probes, got %v", len(test.names), state.Probes)
+			}
+		})
+	}
+}
+
+func TestProbesDataSourceProperties(t *testing.T) {
+	resp := testDataSourceRead(t, NewProbesDataSource(), testListClient(t, "probe", http.StatusOK, testProbes), nil)
+	var state probesDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatal(diags)
+	}
+	expected := probeModel{
+		Name:      types.StringValue("memory"),
+		Metric:    types.StringValue("memory:free"),
+		Condition: types.StringValue("ls"),
+		Threshold: types.StringValue("100"),
+		Type:      types.StringValue("BASH"),
+		Nature:    types.StringValue("penalty"),
+		Behaviour: types.StringValue("scale"),
+	}
+	if len(state.Probes) == 0 || !reflect.DeepEqual(state.Probes[0], expected) {
+		t.Errorf("expected the probe %v, got %v", expected, state.Probes)
+	}
+}
+
+func TestProbesDataSourceFailure(t *testing.T) {
+	resp := testDataSourceRead(t, NewProbesDataSource(), testListClient(t, "probe", http.StatusOK, `{"status":"500","message":"failure"}`), nil)
+	if got := testDataSourceError(resp); got != "Unable to List Amenesik Probes" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
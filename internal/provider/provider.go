@@ -3,19 +3,26 @@ package provider
 
 import (
   "context"
+  "errors"
   "os"
+  "strconv"
   "github.com/hashicorp/terraform-plugin-framework/datasource"
+  "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+  "github.com/hashicorp/terraform-plugin-framework/function"
   "github.com/hashicorp/terraform-plugin-framework/path"
   "github.com/hashicorp/terraform-plugin-framework/provider"
   "github.com/hashicorp/terraform-plugin-framework/provider/schema"
   "github.com/hashicorp/terraform-plugin-framework/resource"
   "github.com/hashicorp/terraform-plugin-framework/types"
   "github.com/hashicorp/terraform-plugin-log/tflog"
+  "terraform-provider-amenesik/internal/simulator"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
     _ provider.Provider = &amenesikProvider{}
+    _ provider.ProviderWithFunctions = &amenesikProvider{}
+    _ provider.ProviderWithEphemeralResources = &amenesikProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -40,6 +47,10 @@ type amenesikProviderModel struct {
     Host    types.String `tfsdk:"host"`
     Account types.String `tfsdk:"account"`
     ApiKey  types.String `tfsdk:"apikey"`
+    Profile types.String `tfsdk:"profile"`
+    CredentialProcess types.String `tfsdk:"credential_process"`
+    Token   types.String `tfsdk:"token"`
+    TokenCache types.Bool `tfsdk:"token_cache"`
 }
 
 // Metadata returns the provider type name.
@@ -60,6 +71,24 @@ func (p *amenesikProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
             },
             "apikey": schema.StringAttribute{
                 Optional: true,
+                Sensitive: true,
+            },
+            "token": schema.StringAttribute{
+                Optional: true,
+                Sensitive: true,
+                Description: "A pre-issued authentication token used instead of the login of the account with its API KEY. May also be provided by the ACE_TOKEN environment variable.",
+            },
+            "token_cache": schema.BoolAttribute{
+                Optional: true,
+                Description: "Whether the login token is stored on disk, by host and account, and reused by subsequent provider processes until it expires. May also be enabled by the ACE_TOKEN_CACHE environment variable.",
+            },
+            "profile": schema.StringAttribute{
+                Optional: true,
+                Description: "The profile of the shared credentials file providing the missing host, account and apikey values. May also be provided by the ACE_PROFILE environment variable.",
+            },
+            "credential_process": schema.StringAttribute{
+                Optional: true,
+                Description: "An external command writing the missing host, account and apikey values to its standard output as a JSON object.",
             },
         },
     }
@@ -106,6 +135,42 @@ func (p *amenesikProvider) Configure(ctx context.Context, req provider.Configure
         )
     }
 
+    if config.Token.IsUnknown() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("token"),
+            "Unknown Amenesik API Token",
+            "The provider cannot create the Amenesik API client as there is an unknown configuration value for the Amenesik API token. "+
+                "Either target apply the source of the value first, set the value statically in the configuration, or use the ACE_TOKEN environment variable.",
+        )
+    }
+
+    if config.Profile.IsUnknown() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("profile"),
+            "Unknown Amenesik Credentials Profile",
+            "The provider cannot create the Amenesik API client as there is an unknown configuration value for the credentials profile. "+
+                "Either target apply the source of the value first, set the value statically in the configuration, or use the ACE_PROFILE environment variable.",
+        )
+    }
+
+    if config.CredentialProcess.IsUnknown() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("credential_process"),
+            "Unknown Amenesik Credential Process",
+            "The provider cannot create the Amenesik API client as there is an unknown configuration value for the credential process. "+
+                "Either target apply the source of the value first, or set the value statically in the configuration.",
+        )
+    }
+
+    if config.TokenCache.IsUnknown() {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("token_cache"),
+            "Unknown Amenesik Token Cache",
+            "The provider cannot create the Amenesik API client as there is an unknown configuration value for the token cache. "+
+                "Either target apply the source of the value first, set the value statically in the configuration, or use the ACE_TOKEN_CACHE environment variable.",
+        )
+    }
+
     if resp.Diagnostics.HasError() {
         return
     }
@@ -131,6 +196,67 @@ func (p *amenesikProvider) Configure(ctx context.Context, req provider.Configure
         apikey = config.ApiKey.ValueString()
     }
 
+    token := os.Getenv("ACE_TOKEN")
+
+    if !config.Token.IsNull() {
+        token = config.Token.ValueString()
+    }
+
+    // Complete the missing values from the credential process and then
+    // from the profile of the shared credentials file, the default
+    // profile being optional.
+
+    profile := os.Getenv("ACE_PROFILE")
+
+    if !config.Profile.IsNull() {
+        profile = config.Profile.ValueString()
+    }
+
+    named := profile != ""
+    if !named {
+        profile = defaultProfile
+    }
+    stored, err := loadProfile(credentialsFile(), profile)
+    if err != nil && (named || !(errors.Is(err, os.ErrNotExist) || errors.Is(err, errProfileNotFound))) {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("profile"),
+            "Invalid Amenesik Credentials Profile",
+            "The provider cannot read the "+profile+" profile of the shared credentials file. "+
+                "Create the profile in the credentials file, or set ACE_CREDENTIALS_FILE to the location of the file.\n\n"+
+                "Error: "+err.Error(),
+        )
+        return
+    }
+
+    resolved := beamCredentials{Host: host, Account: account, ApiKey: apikey, Token: token}
+
+    process := stored.CredentialProcess
+
+    if !config.CredentialProcess.IsNull() {
+        process = config.CredentialProcess.ValueString()
+    }
+
+    if process != "" && (host == "" || (account == "" || apikey == "") && token == "") {
+        tflog.Debug(ctx,"Running Amenesik credential process")
+        processed, err := runCredentialProcess(ctx, process)
+        if err != nil {
+            resp.Diagnostics.AddAttributeError(
+                path.Root("credential_process"),
+                "Failed Amenesik Credential Process",
+                "The provider cannot obtain the Amenesik API credentials from the credential process.\n\n"+
+                    "Error: "+err.Error(),
+            )
+            return
+        }
+        resolved.merge(processed)
+    }
+    resolved.merge(stored)
+
+    host = resolved.Host
+    account = resolved.Account
+    apikey = resolved.ApiKey
+    token = resolved.Token
+
     // If any of the expected configurations are missing, return
     // errors with provider-specific guidance.
 
@@ -139,27 +265,29 @@ func (p *amenesikProvider) Configure(ctx context.Context, req provider.Configure
             path.Root("host"),
             "Missing Amenesik API Host",
             "The provider cannot create the Amenesik API client as there is a missing or empty value for the Amenesik API host. "+
-                "Set the host value in the configuration or use the ACE_HOST environment variable. "+
+                "Set the host value in the configuration, use the ACE_HOST environment variable or a credentials profile. "+
                 "If either is already set, ensure the value is not empty.",
         )
     }
 
-    if account == "" {
+    // The account and API KEY are not required with a token
+
+    if account == "" && token == "" {
         resp.Diagnostics.AddAttributeError(
             path.Root("account"),
             "Missing Amenesik API Account",
             "The provider cannot create the Amenesik API client as there is a missing or empty value for the Amenesik API account. "+
-                "Set the account value in the configuration or use the ACE_ACCOUNT environment variable. "+
+                "Set the account value in the configuration, use the ACE_ACCOUNT environment variable or a credentials profile. "+
                 "If either is already set, ensure the value is not empty.",
         )
     }
 
-    if apikey == "" {
+    if apikey == "" && token == "" {
         resp.Diagnostics.AddAttributeError(
             path.Root("apikey"),
             "Missing Amenesik API KEY",
             "The provider cannot create the Amenesik API client as there is a missing or empty value for the Amenesik API KEY. "+
-                "Set the API KEY in the configuration or better still use the ACE_APIKEY environment variable. "+
+                "Set the API KEY in the configuration or better still use the ACE_APIKEY environment variable, a credentials profile or a credential process, or provide a token instead. "+
                 "If either is already set, ensure the value is not empty.",
         )
     }
@@ -168,14 +296,41 @@ func (p *amenesikProvider) Configure(ctx context.Context, req provider.Configure
         return
     }
 
+    // A mock://name host selects the in-process ACE simulator of the
+    // name, for offline development and tests, when enabled by the
+    // ACE_SIMULATOR environment variable.
+
+    url, simulated, err := simulator.Host(host)
+    if err != nil {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("host"),
+            "Amenesik Simulator Not Enabled",
+            "The host "+host+" selects the in-process Amenesik simulator, which is intended for development and tests only. "+
+                "Set the ACE_SIMULATOR environment variable to true to use it, or set the host of the Amenesik Enterprise Cloud.\n\n"+
+                "Error: "+err.Error(),
+        )
+        return
+    }
+    if simulated {
+        tflog.Info(ctx,"Using the Amenesik simulator "+host)
+        host = url
+    }
+
+    ctx = withLogSecrets(ctx, apikey, token)
     ctx = tflog.SetField(ctx,"amenesik_host", host)
     ctx = tflog.SetField(ctx,"amenesik_account", account)
-    ctx = tflog.SetField(ctx,"amenesik_apikey", apikey)
 
     tflog.Debug(ctx,"Creating Amenesik client")
 
-    // Create a new Amenesik client using the configuration values
-    client, err := NewClient(ctx,host,account,apikey)
+    // Create a new Amenesik client using the configuration values,
+    // a token replacing the login of the account, the authentication
+    // being deferred until the first API request
+    var client *Client
+    if token != "" {
+        client, err = NewClientWithToken(ctx,host,account,token)
+    } else {
+        client, err = NewClient(ctx,host,account,apikey)
+    }
     if err != nil {
         resp.Diagnostics.AddError(
             "Unable to Create Amenesik API Client",
@@ -186,16 +341,46 @@ func (p *amenesikProvider) Configure(ctx context.Context, req provider.Configure
         return
     }
 
-    // Make the Amenesik client available during DataSource and Resource
-    // type Configure methods.
+    // The login token is only cached when enabled, the pre-issued
+    // tokens being managed by their issuer, and never while a cassette
+    // is recorded or replayed, which must include the login.
+
+    tokenCache, _ := strconv.ParseBool(os.Getenv("ACE_TOKEN_CACHE"))
+
+    if !config.TokenCache.IsNull() {
+        tokenCache = config.TokenCache.ValueBool()
+    }
+
+    if os.Getenv("ACE_RECORD") != "" || os.Getenv("ACE_REPLAY") != "" {
+        tokenCache = false
+    }
+
+    if dir := tokenCacheDir(); tokenCache && token == "" && dir != "" {
+        client.UseTokenCache(dir)
+    }
+
+    // Make the Amenesik client available during DataSource, Resource
+    // and EphemeralResource type Configure methods.
     resp.DataSourceData = client
     resp.ResourceData = client
+    resp.EphemeralResourceData = client
     tflog.Info(ctx,"Configured Amenesik client", map[string]any{"success":true})
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *amenesikProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-  return nil
+    return []func() datasource.DataSource{
+        NewTemplatesDataSource,
+        NewAppDataSource,
+        NewAppNodesDataSource,
+        NewCategoriesDataSource,
+        NewRegionsDataSource,
+        NewBeamDataSource,
+        NewAccountDataSource,
+        NewProbesDataSource,
+        NewMetricsDataSource,
+        NewNodeTypesDataSource,
+    }
 }
 
 // Resources defines the resources implemented in the provider.
@@ -203,7 +388,21 @@ func (p *amenesikProvider) Resources(_ context.Context) []func() resource.Resour
     return []func() resource.Resource{
         NewAppResource,
 	NewBeamResource,
+	NewNodeTypeResource,
+	NewProbeResource,
     }
 }
 
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *amenesikProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+    return []func() ephemeral.EphemeralResource{
+        NewSessionEphemeralResource,
+    }
+}
 
+// Functions defines the functions implemented in the provider.
+func (p *amenesikProvider) Functions(_ context.Context) []func() function.Function {
+    return []func() function.Function{
+        NewBeamTopologyFunction,
+    }
+}
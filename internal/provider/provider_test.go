@@ -4,31 +4,207 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"terraform-provider-amenesik/internal/simulator"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
 // The factory function is called for each Terraform CLI command to create a provider
 // server that the CLI can connect to and interact with.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"amenesik": providerserver.NewProtocol6WithError(New("test")()),
 }
 
-// testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the scaffolding provider.
-// It allows for testing assertions on data returned by an ephemeral resource during Open.
-// The echoprovider is used to arrange tests by echoing ephemeral data into the Terraform state.
-// This lets the data be referenced in test assertions with state checks.
-var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
-	"echo":        echoprovider.NewProviderServer(),
+func testAccPreCheck(t *testing.T) {
+	// The acceptance tests run against the in-process ACE simulator, whose
+	// instance transitions are shortened, so that no credentials are required.
+	statusPollInterval = 20 * time.Millisecond
 }
 
-func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+// testAccSimulator returns the simulator of the test, shortening its instance
+// transitions, together with the provider configuration addressing it.
+func testAccSimulator(t *testing.T) (*simulator.Simulator, string) {
+	t.Setenv(simulator.EnableVariable, "true")
+	name := strings.ToLower(regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(t.Name(), "-"))
+	sim := simulator.Named(name)
+	sim.SetDelay(50 * time.Millisecond)
+	t.Cleanup(func() { simulator.Remove(name) })
+	return sim, fmt.Sprintf(`
+provider "amenesik" {
+  host    = "%s%s"
+  account = "test"
+  apikey  = "test"
+}
+`, simulator.Scheme, name)
+}
+
+// testAccCheckDestroyed verifies that the simulator holds no models once destroyed.
+func testAccCheckDestroyed(sim *simulator.Simulator) func(*terraform.State) error {
+	return func(_ *terraform.State) error {
+		if models := sim.Models(); len(models) > 0 {
+			return fmt.Errorf("models remain after destroy: %s", strings.Join(models, ", "))
+		}
+		return nil
+	}
+}
+
+// testDataSourceRead reads the data source with the client, the attributes
+// of its configuration being those given, or null.
+func testDataSourceRead(t *testing.T, d datasource.DataSource, c *Client, attributes map[string]tftypes.Value) *datasource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+	var schema datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schema)
+	var configure datasource.ConfigureResponse
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: c}, &configure)
+	if configure.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics %v", configure.Diagnostics)
+	}
+
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schema.Schema, Raw: tftypes.NewValue(objectType, values)}}, resp)
+	return resp
+}
+
+// testDataSourceError returns the summary of the first error of the diagnostics.
+func testDataSourceError(resp *datasource.ReadResponse) string {
+	for _, d := range resp.Diagnostics.Errors() {
+		return d.Summary()
+	}
+	return ""
+}
+
+// testObjectValue returns the object value of the schema type, the attributes
+// being those given, or null.
+func testObjectValue(objectType tftypes.Object, attributes map[string]tftypes.Value) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+// testProviderServer returns the provider server configured with the attributes.
+func testProviderServer(t *testing.T, attributes map[string]tftypes.Value) tfprotov6.ProviderServer {
+	t.Helper()
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New("test")())()
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectType := schema.Provider.ValueType().(tftypes.Object)
+	config, err := tfprotov6.NewDynamicValue(objectType, testObjectValue(objectType, attributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected configure diagnostic %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return server
+}
+
+// testResourceConfig returns the configuration of the resource, the attributes
+// being those given, or null.
+func testResourceConfig(r resource.Resource, attributes map[string]tftypes.Value) tfsdk.Config {
+	ctx := context.Background()
+	var schema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	return tfsdk.Config{Schema: schema.Schema, Raw: testObjectValue(objectType, attributes)}
+}
+
+// testResourcePlan returns the plan of the resource, the attributes being
+// those given, or null.
+func testResourcePlan(r resource.Resource, attributes map[string]tftypes.Value) tfsdk.Plan {
+	config := testResourceConfig(r, attributes)
+	return tfsdk.Plan{Schema: config.Schema, Raw: config.Raw}
+}
+
+// testResourceState returns the state of the resource, the attributes being
+// those given, or null.
+func testResourceState(r resource.Resource, attributes map[string]tftypes.Value) tfsdk.State {
+	config := testResourceConfig(r, attributes)
+	return tfsdk.State{Schema: config.Schema, Raw: config.Raw}
+}
+
+// testResourceNullState returns the null state of the resource.
+func testResourceNullState(r resource.Resource) tfsdk.State {
+	state := testResourceState(r, nil)
+	state.Raw = tftypes.NewValue(state.Raw.Type(), nil)
+	return state
+}
+
+// testResourceConfigure configures the resource with the client.
+func testResourceConfigure(t *testing.T, r resource.Resource, c *Client) resource.Resource {
+	t.Helper()
+	var resp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{ProviderData: c}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics %v", resp.Diagnostics)
+	}
+	return r
+}
+
+// testResourceError returns the summary of the first error of the diagnostics.
+func testResourceError(diags diag.Diagnostics) string {
+	for _, d := range diags.Errors() {
+		return d.Summary()
+	}
+	return ""
+}
+
+// testResourceCreate creates the resource of the attributes.
+func testResourceCreate(t *testing.T, r resource.Resource, attributes map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+	resp := resource.CreateResponse{State: testResourceNullState(r)}
+	r.Create(context.Background(), resource.CreateRequest{Plan: testResourcePlan(r, attributes), Config: testResourceConfig(r, attributes)}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	return resp.State
+}
+
+// testResourceRead reads the resource of the state.
+func testResourceRead(t *testing.T, r resource.Resource, state tfsdk.State) tfsdk.State {
+	t.Helper()
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	return resp.State
 }
@@ -0,0 +1,149 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &regionsDataSource{}
+    _ datasource.DataSourceWithConfigure = &regionsDataSource{}
+)
+
+// NewRegionsDataSource is a helper function to simplify the provider implementation.
+func NewRegionsDataSource() datasource.DataSource {
+    return &regionsDataSource{}
+}
+
+// regions Data Source is the data source implementation.
+type regionsDataSource struct{
+	client *Client
+}
+
+// regionsDataSourceModel maps the data source schema data.
+type regionsDataSourceModel struct {
+    Category    types.String     `tfsdk:"category"`
+    Names       []types.String   `tfsdk:"names"`
+    Regions     []regionModel    `tfsdk:"regions"`
+}
+
+// the provisioning region model
+type regionModel struct {
+    Name        types.String	`tfsdk:"name"`
+    Category    types.String	`tfsdk:"category"`
+}
+
+func (d *regionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+// Schema defines the schema for the data source.
+func (d *regionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the provisioning regions offered by the categories enabled for the account.",
+        Attributes: map[string]schema.Attribute{
+            "category": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list the regions offered by this provisioning category.",
+            },
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+                Description: "The distinct names of the listed regions.",
+            },
+            "regions": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "category": schema.StringAttribute{
+                            Computed: true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *regionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config regionsDataSourceModel
+    tflog.Info(ctx,"AMENESIK:REGIONS ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    categories, err := d.client.ListBeamCategories(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik Provisioning Regions",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    found := config.Category.IsNull()
+    seen := map[string]bool{}
+    config.Names = []types.String{}
+    config.Regions = []regionModel{}
+    for _, c := range categories {
+        if !config.Category.IsNull() && c.Name != config.Category.ValueString() {
+            continue
+        }
+        found = true
+        for _, r := range c.Regions {
+            config.Regions = append(config.Regions, regionModel{
+                Name:     types.StringValue(r),
+                Category: types.StringValue(c.Name),
+            })
+            if !seen[r] {
+                seen[r] = true
+                config.Names = append(config.Names, types.StringValue(r))
+            }
+        }
+    }
+
+    if !found {
+        resp.Diagnostics.AddError(
+            "Amenesik Provisioning Category Not Found",
+            "The provisioning category "+config.Category.ValueString()+" is not enabled for the account.",
+        )
+        return
+    }
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:REGIONS LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *regionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
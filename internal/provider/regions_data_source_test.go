@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRegionsDataSource(t *testing.T) {
+	tests := map[string]struct {
+		category string
+		names    []string
+		regions  []string
+		error    string
+	}{
+		"all categories": {
+			names:   []string{"france", "ireland", "germany"},
+			regions: []string{"amazonec2:france", "amazonec2:ireland", "windowsazure:france", "windowsazure:germany"},
+		},
+		"one category": {
+			category: "windowsazure",
+			names:    []string{"france", "germany"},
+			regions:  []string{"windowsazure:france", "windowsazure:germany"},
+		},
+		"category without regions": {
+			category: "openstack",
+			names:    []string{},
+			regions:  []string{},
+		},
+		"unknown category": {
+			category: "gce",
+			error:    "Amenesik Provisioning Category Not Found",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			category := tftypes.NewValue(tftypes.String, nil)
+			if test.category != "" {
+				category = tftypes.NewValue(tftypes.String, test.category)
+			}
+			resp := testDataSourceRead(t, NewRegionsDataSource(), testCategoriesClient(t, http.StatusOK, testCategories), map[string]tftypes.Value{
+				"category": category,
+			})
+			if got := testDataSourceError(resp); got != test.error {
+				t.Fatalf("expected the error %q, got %q: %v", test.error, got, resp.Diagnostics)
+			}
+			if test.error != "" {
+				return
+			}
+			var state regionsDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatal(diags)
+			}
+			regions := []string{}
+			for _, r := range state.Regions {
+				regions = append(regions, r.Category.ValueString()+":"+r.Name.ValueString())
+			}
+			if got := testStrings(state.Names); !reflect.DeepEqual(got, test.names) {
+				t.Errorf("expected the names %v, got %v", test.names, got)
+			}
+			if !reflect.DeepEqual(regions, test.regions) {
+				t.Errorf("expected the regions %v, got %v", test.regions, regions)
+			}
+		})
+	}
+}
+
+func TestRegionsDataSourceFailure(t *testing.T) {
+	resp := testDataSourceRead(t, NewRegionsDataSource(), testCategoriesClient(t, http.StatusUnauthorized, "unauthorized"), nil)
+	if got := testDataSourceError(resp); got != "Unable to List Amenesik Provisioning Regions" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
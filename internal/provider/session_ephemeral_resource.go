@@ -0,0 +1,140 @@
+package provider
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+    "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ ephemeral.EphemeralResource              = &sessionEphemeralResource{}
+    _ ephemeral.EphemeralResourceWithConfigure = &sessionEphemeralResource{}
+    _ ephemeral.EphemeralResourceWithClose     = &sessionEphemeralResource{}
+)
+
+// the private data key of the session token to be revoked on close
+const sessionTokenKey = "token"
+
+// NewSessionEphemeralResource is a helper function to simplify the provider implementation.
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+    return &sessionEphemeralResource{}
+}
+
+// session Ephemeral Resource is the ephemeral resource implementation.
+type sessionEphemeralResource struct{
+	client *Client
+}
+
+// sessionEphemeralResourceModel maps the ephemeral resource schema data.
+type sessionEphemeralResourceModel struct {
+    Token       types.String     `tfsdk:"token"`
+    Expires     types.String     `tfsdk:"expires"`
+    Account     types.String     `tfsdk:"account"`
+    User        types.String     `tfsdk:"user"`
+    Role        types.String     `tfsdk:"role"`
+}
+
+func (e *sessionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *sessionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Opens a short-lived ACE session for external tools, the token being revoked when Terraform no longer needs it.",
+        Attributes: map[string]schema.Attribute{
+            "token": schema.StringAttribute{
+                Computed: true,
+                Sensitive: true,
+                Description: "The session authentication token.",
+            },
+            "expires": schema.StringAttribute{
+                Computed: true,
+                Description: "The expiry of the session token as returned by ACE.",
+            },
+            "account": schema.StringAttribute{
+                Computed: true,
+            },
+            "user": schema.StringAttribute{
+                Computed: true,
+            },
+            "role": schema.StringAttribute{
+                Computed: true,
+            },
+        },
+    }
+}
+
+// Open performs the login of a new session.
+func (e *sessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+    tflog.Info(ctx,"AMENESIK:SESSION ENTER:OPEN");
+
+    session, err := e.client.NewSession(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to Open Amenesik Session",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    result := sessionEphemeralResourceModel{
+        Token:   types.StringValue(session.auth),
+        Expires: types.StringValue(session.expires),
+        Account: types.StringValue(session.account),
+        User:    types.StringValue(session.user),
+        Role:    types.StringValue(session.role),
+    }
+    resp.Diagnostics.Append(resp.Result.Set(ctx, &result)...)
+    token, _ := json.Marshal(session.auth)
+    resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionTokenKey, token)...)
+    tflog.Info(ctx,"AMENESIK:SESSION LEAVE:OPEN: SUCCESS");
+}
+
+// Close revokes the session token once Terraform no longer needs it.
+func (e *sessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+    tflog.Info(ctx,"AMENESIK:SESSION ENTER:CLOSE");
+    value, diags := req.Private.GetKey(ctx, sessionTokenKey)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() || value == nil {
+        return
+    }
+    var token string
+    if err := json.Unmarshal(value, &token); err != nil {
+        return
+    }
+
+    // the token expires anyway so a failed revocation is only logged
+    if err := e.client.EndSession(ctx, token); err != nil {
+        tflog.Warn(ctx,"AMENESIK:SESSION ERROR: END SESSION: "+err.Error());
+        return
+    }
+    tflog.Info(ctx,"AMENESIK:SESSION LEAVE:CLOSE: SUCCESS");
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *sessionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Ephemeral Resource Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    e.client = client
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+// testSessionOpen opens the session ephemeral resource of the provider server.
+func testSessionOpen(t *testing.T, server tfprotov6.ProviderServer) (*tfprotov6.OpenEphemeralResourceResponse, map[string]tftypes.Value) {
+	t.Helper()
+	ctx := context.Background()
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectType := schema.EphemeralResourceSchemas["amenesik_session"].ValueType().(tftypes.Object)
+	config, err := tfprotov6.NewDynamicValue(objectType, testObjectValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{TypeName: "amenesik_session", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result == nil {
+		return resp, nil
+	}
+	value, err := resp.Result.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := map[string]tftypes.Value{}
+	if err := value.As(&result); err != nil {
+		t.Fatal(err)
+	}
+	return resp, result
+}
+
+// testSessionString returns the string of the result attribute.
+func testSessionString(t *testing.T, result map[string]tftypes.Value, name string) string {
+	t.Helper()
+	var s string
+	if err := result[name].As(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSessionEphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+		"account": tftypes.NewValue(tftypes.String, "test"),
+		"apikey":  tftypes.NewValue(tftypes.String, "apikey"),
+	})
+
+	resp, result := testSessionOpen(t, server)
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics %v", resp.Diagnostics)
+	}
+	token := testSessionString(t, result, "token")
+	if token == "" || testSessionString(t, result, "account") != "test" || testSessionString(t, result, "role") != "admin" {
+		t.Fatalf("unexpected session %v", result)
+	}
+	if _, ok := ParseBeamExpiry(testSessionString(t, result, "expires")); !ok {
+		t.Errorf("unexpected expiry %v", result["expires"])
+	}
+
+	// the session token is usable until the ephemeral resource is closed
+	c, err := NewClientWithToken(ctx, sim.URL(), "test", token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.WhoAmI(ctx); err != nil {
+		t.Fatalf("expected a valid session token, got %v", err)
+	}
+	closed, err := server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{TypeName: "amenesik_session", Private: resp.Private})
+	if err != nil || len(closed.Diagnostics) != 0 {
+		t.Fatalf("unexpected close failure %v %v", err, closed)
+	}
+	if sim.Requests("logout") != 1 {
+		t.Errorf("expected the session to be revoked")
+	}
+	c, _ = NewClientWithToken(ctx, sim.URL(), "test", token)
+	if _, err := c.WhoAmI(ctx); !errors.Is(err, ErrBeamTokenExpired) {
+		t.Errorf("expected a revoked session token, got %v", err)
+	}
+
+	// a second session is distinct from the first
+	_, second := testSessionOpen(t, server)
+	if testSessionString(t, second, "token") == token {
+		t.Errorf("expected a new session token")
+	}
+}
+
+func TestSessionEphemeralResourceFailure(t *testing.T) {
+	sim := simulator.New("test", "apikey")
+	sim.Start()
+	defer sim.Close()
+	tests := map[string]map[string]tftypes.Value{
+		"rejected apikey": {
+			"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+			"account": tftypes.NewValue(tftypes.String, "test"),
+			"apikey":  tftypes.NewValue(tftypes.String, "wrong"),
+		},
+		"token authentication": {
+			"host":    tftypes.NewValue(tftypes.String, sim.URL()),
+			"account": tftypes.NewValue(tftypes.String, "test"),
+			"token":   tftypes.NewValue(tftypes.String, "pre-issued-token"),
+		},
+	}
+	for name, attributes := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, _ := testSessionOpen(t, testProviderServer(t, attributes))
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Unable to Open Amenesik Session" {
+				t.Errorf("unexpected diagnostics %v", resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+    "context"
+    "fmt"
+    "strings"
+    "github.com/hashicorp/terraform-plugin-framework/datasource"
+    "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+    "github.com/hashicorp/terraform-plugin-framework/path"
+    "github.com/hashicorp/terraform-plugin-framework/types"
+    "github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+    _ datasource.DataSource              = &templatesDataSource{}
+    _ datasource.DataSourceWithConfigure = &templatesDataSource{}
+)
+
+// NewTemplatesDataSource is a helper function to simplify the provider implementation.
+func NewTemplatesDataSource() datasource.DataSource {
+    return &templatesDataSource{}
+}
+
+// templates Data Source is the data source implementation.
+type templatesDataSource struct{
+	client *Client
+}
+
+// templatesDataSourceModel maps the data source schema data.
+type templatesDataSourceModel struct {
+    Name         types.String     `tfsdk:"name"`
+    NamePrefix   types.String     `tfsdk:"name_prefix"`
+    Distribution types.String     `tfsdk:"distribution"`
+    Database     types.String     `tfsdk:"database"`
+    Size         types.String     `tfsdk:"size"`
+    Names        []types.String   `tfsdk:"names"`
+    Templates    []templateModel  `tfsdk:"templates"`
+}
+
+// the beam template model
+type templateModel struct {
+    Name         types.String	`tfsdk:"name"`
+    Title        types.String	`tfsdk:"title"`
+    Author       types.String	`tfsdk:"author"`
+    Version      types.String	`tfsdk:"version"`
+    Date         types.String	`tfsdk:"date"`
+    Distribution types.String	`tfsdk:"distribution"`
+    Database     types.String	`tfsdk:"database"`
+    Size         types.String	`tfsdk:"size"`
+}
+
+func (d *templatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+    resp.TypeName = req.ProviderTypeName + "_templates"
+}
+
+// Schema defines the schema for the data source.
+func (d *templatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+    resp.Schema = schema.Schema{
+        Description: "Lists the BEAM templates of the catalogue visible to the provisioning account.",
+        Attributes: map[string]schema.Attribute{
+            "name": schema.StringAttribute{
+                Optional: true,
+                Description: "The exact name of a template that must exist.",
+            },
+            "name_prefix": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list templates whose name starts with this prefix.",
+            },
+            "distribution": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list templates for this operating system distribution, such as u2004 or rhel9.",
+            },
+            "database": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list templates for this database, such as mysql, mariadb or postgres.",
+            },
+            "size": schema.StringAttribute{
+                Optional: true,
+                Description: "Only list templates of this size, such as small, medium, large or huge.",
+            },
+            "names": schema.ListAttribute{
+                Computed: true,
+                ElementType: types.StringType,
+            },
+            "templates": schema.ListNestedAttribute{
+                Computed: true,
+                NestedObject: schema.NestedAttributeObject{
+                    Attributes: map[string]schema.Attribute{
+                        "name": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "title": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "author": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "version": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "date": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "distribution": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "database": schema.StringAttribute{
+                            Computed: true,
+                        },
+                        "size": schema.StringAttribute{
+                            Computed: true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+// the distinguishing name segments of the catalogue templates
+var templateFacets = map[string][]string{
+    "Database": {"mysql", "mariadb", "postgres"},
+    "Size":     {"small", "medium", "large", "huge"},
+}
+
+// ----------------------------------------------------
+// TEMPLATE FACET
+// ----------------------------------------------------
+// Returns the value of the tag of a template, or when
+// the tag is absent the matching segment of its name,
+// as in "abal64-u2004-mysql-small-template", where
+// the distribution is the segment following the first
+// unless it is the value of another facet.
+// ----------------------------------------------------
+func templateFacet(t BeamTemplate, tag string) string {
+    if v := t.Tags[tag]; v != "" {
+        return v
+    }
+    segments := strings.Split(strings.TrimSuffix(t.Name, "-template"), "-")
+    if tag == "Distribution" {
+        if len(segments) > 1 && !isFacetValue(segments[1]) {
+            return segments[1]
+        }
+        return ""
+    }
+    for _, s := range segments {
+        for _, v := range templateFacets[tag] {
+            if s == v {
+                return s
+            }
+        }
+    }
+    return ""
+}
+
+// returns true when the name segment is the value of a facet
+func isFacetValue(segment string) bool {
+    for _, values := range templateFacets {
+        for _, v := range values {
+            if segment == v {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// returns true when the filter is not set or matches the value
+func facetMatch(filter types.String, value string) bool {
+    return filter.IsNull() || strings.EqualFold(filter.ValueString(), value)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *templatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+    var config templatesDataSourceModel
+    tflog.Info(ctx,"AMENESIK:TEMPLATES ENTER:READ: Get Config");
+    diags := req.Config.Get(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    if resp.Diagnostics.HasError() {
+        return
+    }
+
+    templates, err := d.client.ListBeamTemplates(ctx)
+    if err != nil {
+        resp.Diagnostics.AddError(
+            "Unable to List Amenesik BEAM Templates",
+            "Amenesik Client Error: "+err.Error(),
+        )
+        return
+    }
+
+    config.Names = []types.String{}
+    config.Templates = []templateModel{}
+    for _, t := range templates {
+        item := templateModel{
+            Name:         types.StringValue(t.Name),
+            Title:        types.StringValue(t.Tags["Title"]),
+            Author:       types.StringValue(t.Tags["Author"]),
+            Version:      types.StringValue(t.Tags["Version"]),
+            Date:         types.StringValue(t.Tags["Date"]),
+            Distribution: types.StringValue(templateFacet(t, "Distribution")),
+            Database:     types.StringValue(templateFacet(t, "Database")),
+            Size:         types.StringValue(templateFacet(t, "Size")),
+        }
+        if !config.Name.IsNull() && t.Name != config.Name.ValueString() {
+            continue
+        }
+        if !config.NamePrefix.IsNull() && !strings.HasPrefix(t.Name, config.NamePrefix.ValueString()) {
+            continue
+        }
+        if !facetMatch(config.Distribution, item.Distribution.ValueString()) ||
+            !facetMatch(config.Database, item.Database.ValueString()) ||
+            !facetMatch(config.Size, item.Size.ValueString()) {
+            continue
+        }
+        config.Names = append(config.Names, item.Name)
+        config.Templates = append(config.Templates, item)
+    }
+
+    if !config.Name.IsNull() && len(config.Templates) == 0 {
+        resp.Diagnostics.AddAttributeError(
+            path.Root("name"),
+            "Amenesik BEAM Template Not Found",
+            "No template named "+config.Name.ValueString()+" matching the filters is visible to the provisioning account.",
+        )
+        return
+    }
+
+    diags = resp.State.Set(ctx, &config)
+    resp.Diagnostics.Append(diags...)
+    tflog.Info(ctx,"AMENESIK:TEMPLATES LEAVE:READ: SUCCESS");
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *templatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+    // Add a nil check when handling ProviderData because Terraform
+    // sets that data after it calls the ConfigureProvider RPC.
+    if req.ProviderData == nil {
+        return
+    }
+
+    client, ok := req.ProviderData.(*Client)
+
+    if !ok {
+        resp.Diagnostics.AddError(
+            "Unexpected Data Source Configure Type",
+            fmt.Sprintf("Expected *Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+        )
+
+        return
+    }
+
+    d.client = client
+}
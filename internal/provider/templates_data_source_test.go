@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTemplateFacet(t *testing.T) {
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		distribution string
+		database     string
+		size         string
+	}{
+		{name: "abal64-u2004-mysql-small-template", distribution: "u2004", database: "mysql", size: "small"},
+		{name: "openabal-rhel9-postgres-medium-template", distribution: "rhel9", database: "postgres", size: "medium"},
+		{name: "openabal-rhel9-template", distribution: "rhel9"},
+		{name: "example-rhel9-small-template", distribution: "rhel9", size: "small"},
+		{name: "openabal-mariadb-huge-template", database: "mariadb", size: "huge"},
+		{name: "openabal-large-template", size: "large"},
+		{name: "openabal-template"},
+		{name: "template"},
+		{name: "webtemplate"},
+		{name: "mybeam", distribution: ""},
+		{
+			name:         "abal64-u2004-mysql-small-template",
+			tags:         map[string]string{"Distribution": "ubuntu", "Size": "tiny"},
+			distribution: "ubuntu",
+			database:     "mysql",
+			size:         "tiny",
+		},
+	}
+	for _, test := range tests {
+		template := BeamTemplate{Name: test.name, Tags: test.tags}
+		for tag, expected := range map[string]string{"Distribution": test.distribution, "Database": test.database, "Size": test.size} {
+			if got := templateFacet(template, tag); got != expected {
+				t.Errorf("templateFacet(%q, %s): expected %q, got %q", test.name, tag, expected, got)
+			}
+		}
+	}
+}
+
+func TestFacetMatch(t *testing.T) {
+	tests := []struct {
+		filter   types.String
+		value    string
+		expected bool
+	}{
+		{types.StringNull(), "", true},
+		{types.StringNull(), "rhel9", true},
+		{types.StringValue("rhel9"), "rhel9", true},
+		{types.StringValue("RHEL9"), "rhel9", true},
+		{types.StringValue("rhel9"), "u2004", false},
+		{types.StringValue("rhel9"), "", false},
+	}
+	for _, test := range tests {
+		if got := facetMatch(test.filter, test.value); got != test.expected {
+			t.Errorf("facetMatch(%s, %q): expected %t, got %t", test.filter, test.value, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// PERSISTENT TOKEN CACHE
+// -------------------------------------------
+// Terraform starts a new provider process for
+// each of its commands. When enabled, the
+// session token of the login is stored on
+// disk, by host and account, and is reused
+// by subsequent provider processes until the
+// token expires, rather than each process
+// performing a new login.
+// -------------------------------------------
+
+package provider
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// a cached token is only reused when it remains valid for at least this duration
+const tokenCacheMargin = time.Minute
+
+// a lock older than this duration was left by a terminated process
+const tokenCacheStaleLock = 30 * time.Second
+
+// the interval between attempts to acquire the lock of a cache file
+var tokenCacheLockInterval = 100 * time.Millisecond
+
+// the interval between refreshes of a held lock, well within tokenCacheStaleLock
+var tokenCacheLockRefresh = tokenCacheStaleLock / 3
+
+// ---------------------------------
+// ACE token cache entry
+// ---------------------------------
+type cachedToken struct {
+    Auth    string `json:"auth"`
+    Account string `json:"account"`
+    User    string `json:"user"`
+    Role    string `json:"role"`
+    Expires string `json:"expires"`
+}
+
+// ---------------------------------
+// ACE token cache of a host account
+// ---------------------------------
+type tokenCache struct {
+    file string
+}
+
+// returns the default token cache directory
+func tokenCacheDir() string {
+    if d := os.Getenv("ACE_TOKEN_CACHE_DIR"); d != "" {
+        return d
+    }
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return ""
+    }
+    return filepath.Join(home, ".amenesik", "cache")
+}
+
+// ----------------------------------------------------------------------
+// NEW TOKEN CACHE ( dir, host, account, apikey )
+// ----------------------------------------------------------------------
+// Returns the cache of the tokens of the account of the host, stored in
+// a file of the directory named by a digest of the host, account and API
+// KEY, so that the token of a login is never reused by a provider whose
+// API KEY was changed or revoked.
+// ----------------------------------------------------------------------
+func newTokenCache(dir string, host string, account string, apikey string) *tokenCache {
+    sum := sha256.Sum256([]byte(host + "\x00" + account + "\x00" + apikey))
+    return &tokenCache{file: filepath.Join(dir, hex.EncodeToString(sum[:])+".json")}
+}
+
+// ----------------------------------------------------------------------
+// LOCK ( )
+// ----------------------------------------------------------------------
+// Acquires the lock of the cache file, shared by all provider processes,
+// returning the function releasing it. The lock is an exclusively
+// created lock file, which is portable to all the supported platforms,
+// whose modification time is refreshed while the lock is held, so that
+// only the locks of terminated processes become stale. The directory,
+// which may already exist, is made accessible to the user only.
+// ----------------------------------------------------------------------
+func (t *tokenCache) lock(ctx context.Context) (func(), error) {
+    dir := filepath.Dir(t.file)
+    if err := os.MkdirAll(dir, 0700); err != nil {
+        return nil, err
+    }
+    if err := os.Chmod(dir, 0700); err != nil {
+        return nil, err
+    }
+    name := t.file + ".lock"
+    for {
+        f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+        if err == nil {
+            f.Close()
+            return holdLock(name), nil
+        }
+        if !errors.Is(err, os.ErrExist) {
+            return nil, err
+        }
+        if info, err := os.Stat(name); err == nil && time.Since(info.ModTime()) > tokenCacheStaleLock {
+            os.Remove(name)
+            continue
+        }
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-time.After(tokenCacheLockInterval):
+        }
+    }
+}
+
+// refreshes the modification time of the lock file until the returned
+// function is called, which releases the lock
+func holdLock(name string) func() {
+    done := make(chan struct{})
+    stopped := make(chan struct{})
+    go func() {
+        defer close(stopped)
+        ticker := time.NewTicker(tokenCacheLockRefresh)
+        defer ticker.Stop()
+        for {
+            select {
+            case <-done:
+                return
+            case now := <-ticker.C:
+                os.Chtimes(name, now, now)
+            }
+        }
+    }()
+    return func() {
+        close(done)
+        <-stopped
+        os.Remove(name)
+    }
+}
+
+// ----------------------------------------------------------------------
+// LOAD ( )
+// ----------------------------------------------------------------------
+// Returns the cached session, which is only valid when the cache holds a
+// token whose expiry is known and not about to be reached.
+// ----------------------------------------------------------------------
+func (t *tokenCache) load() (BeamToken, bool) {
+    data, err := os.ReadFile(t.file)
+    if err != nil {
+        return BeamToken{}, false
+    }
+    var entry cachedToken
+    if err := json.Unmarshal(data, &entry); err != nil || entry.Auth == "" {
+        return BeamToken{}, false
+    }
+    expiry, ok := ParseBeamExpiry(entry.Expires)
+    if !ok || time.Until(expiry) < tokenCacheMargin {
+        return BeamToken{}, false
+    }
+    return BeamToken{
+        status:  "200",
+        auth:    entry.Auth,
+        account: entry.Account,
+        user:    entry.User,
+        role:    entry.Role,
+        expires: entry.Expires,
+    }, true
+}
+
+// ----------------------------------------------------------------------
+// STORE ( session )
+// ----------------------------------------------------------------------
+// Stores the session token in a file created readable by the user only,
+// replacing the cache file atomically. Tokens without a known expiry are
+// not stored.
+// ----------------------------------------------------------------------
+func (t *tokenCache) store(session BeamToken) error {
+    if _, ok := ParseBeamExpiry(session.expires); !ok {
+        return nil
+    }
+    data, _ := json.Marshal(cachedToken{
+        Auth:    session.auth,
+        Account: session.account,
+        User:    session.user,
+        Role:    session.role,
+        Expires: session.expires,
+    })
+    f, err := os.CreateTemp(filepath.Dir(t.file), ".token-*")
+    if err != nil {
+        return err
+    }
+    defer os.Remove(f.Name())
+    if _, err := f.Write(data); err != nil {
+        f.Close()
+        return err
+    }
+    if err := f.Close(); err != nil {
+        return err
+    }
+    return os.Rename(f.Name(), t.file)
+}
+
+// removes the cached token, rejected by ACE
+func (t *tokenCache) remove() {
+    os.Remove(t.file)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"terraform-provider-amenesik/internal/simulator"
+)
+
+func TestNewTokenCache(t *testing.T) {
+	file := newTokenCache("dir", "ace.example.com", "shop", "apikey").file
+	tests := map[string]struct {
+		cache *tokenCache
+		same  bool
+	}{
+		"same credentials": {newTokenCache("dir", "ace.example.com", "shop", "apikey"), true},
+		"other host":       {newTokenCache("dir", "other.example.com", "shop", "apikey"), false},
+		"other account":    {newTokenCache("dir", "ace.example.com", "other", "apikey"), false},
+		"other apikey":     {newTokenCache("dir", "ace.example.com", "shop", "rotated-apikey"), false},
+	}
+	for name, test := range tests {
+		if (test.cache.file == file) != test.same {
+			t.Errorf("%s: unexpected cache file %s", name, test.cache.file)
+		}
+	}
+	if filepath.Dir(file) != "dir" || filepath.Ext(file) != ".json" {
+		t.Errorf("unexpected cache file %s", file)
+	}
+}
+
+func TestTokenCacheStore(t *testing.T) {
+	valid := fmt.Sprint(time.Now().Add(time.Hour).Unix())
+	tests := map[string]struct {
+		expires string
+		stored  bool
+		loaded  bool
+	}{
+		"valid":        {expires: valid, stored: true, loaded: true},
+		"about to end": {expires: fmt.Sprint(time.Now().Add(tokenCacheMargin / 2).Unix()), stored: true},
+		"expired":      {expires: "2001-02-03T04:05:06Z", stored: true},
+		"unknown":      {expires: "", stored: false},
+		"unrecognised": {expires: "tomorrow", stored: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := newTokenCache(t.TempDir(), "ace.example.com", "shop", "apikey")
+			session := BeamToken{status: "200", auth: "cached-token", account: "shop", user: "shop", role: "admin", expires: test.expires}
+			if err := cache.store(session); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(cache.file)
+			if (err == nil) != test.stored {
+				t.Fatalf("expected the token to be stored %t, got %v", test.stored, err)
+			}
+			if err == nil && runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+				t.Errorf("expected a file readable by the user only, got %v", info.Mode())
+			}
+			loaded, ok := cache.load()
+			if ok != test.loaded || (ok && loaded != session) {
+				t.Errorf("expected the token to be loaded %t, got %+v %t", test.loaded, loaded, ok)
+			}
+		})
+	}
+}
+
+func TestTokenCacheLoadInvalid(t *testing.T) {
+	cache := newTokenCache(t.TempDir(), "ace.example.com", "shop", "apikey")
+	for _, content := range []string{"", "not json", `{"expires":"4102444800"}`} {
+		if err := os.WriteFile(cache.file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if session, ok := cache.load(); ok {
+			t.Errorf("unexpected session %+v of the cache %q", session, content)
+		}
+	}
+	cache.remove()
+	if _, err := os.Stat(cache.file); !os.IsNotExist(err) {
+		t.Errorf("expected the cache file to be removed, got %v", err)
+	}
+}
+
+func TestTokenCacheLock(t *testing.T) {
+	testTokenCacheLockInterval(t, 10*time.Millisecond)
+	dir := filepath.Join(t.TempDir(), "cache")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cache := newTokenCache(dir, "ace.example.com", "shop", "apikey")
+	unlock, err := cache.lock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(dir); err != nil || (runtime.GOOS != "windows" && info.Mode().Perm() != 0700) {
+		t.Errorf("expected a directory accessible to the user only, got %v %v", info.Mode(), err)
+	}
+
+	// the lock is not acquired while held
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := cache.lock(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the held lock to be awaited, got %v", err)
+	}
+
+	// the lock is acquired once released
+	unlock()
+	if unlock, err = cache.lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if _, err := os.Stat(cache.file + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected the lock file to be removed, got %v", err)
+	}
+}
+
+func TestTokenCacheLockRefresh(t *testing.T) {
+	testTokenCacheLockInterval(t, 10*time.Millisecond)
+	cache := newTokenCache(t.TempDir(), "ace.example.com", "shop", "apikey")
+	name := cache.file + ".lock"
+	unlock, err := cache.lock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	// the held lock is refreshed rather than becoming stale
+	old := time.Now().Add(-2 * tokenCacheStaleLock)
+	if err := os.Chtimes(name, old, old); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * tokenCacheLockRefresh)
+	if info, err := os.Stat(name); err != nil || time.Since(info.ModTime()) > tokenCacheStaleLock {
+		t.Fatalf("expected the held lock to be refreshed, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := cache.lock(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected the refreshed lock to be awaited, got %v", err)
+	}
+}
+
+func TestTokenCacheStaleLock(t *testing.T) {
+	testTokenCacheLockInterval(t, 10*time.Millisecond)
+	cache := newTokenCache(t.TempDir(), "ace.example.com", "shop", "apikey")
+	name := cache.file + ".lock"
+	if err := os.WriteFile(name, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the lock of a terminated process is taken over
+	old := time.Now().Add(-2 * tokenCacheStaleLock)
+	if err := os.Chtimes(name, old, old); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := cache.lock(ctx)
+	if err != nil {
+		t.Fatalf("expected the stale lock to be taken over, got %v", err)
+	}
+	unlock()
+}
+
+func TestCachedLogin(t *testing.T) {
+	ctx := context.Background()
+	sim := simulator.New("test", "")
+	sim.Start()
+	defer sim.Close()
+	dir := t.TempDir()
+
+	newClient := func(apikey string) *Client {
+		c, err := NewClient(ctx, sim.URL(), "test", apikey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.UseTokenCache(dir)
+		return c
+	}
+
+	// the token of the first login is reused by the following clients
+	first := newClient("apikey")
+	if _, err := first.ListBeamTemplates(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newClient("apikey").ListBeamTemplates(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if logins := sim.Requests("login"); logins != 1 {
+		t.Fatalf("expected the cached token to be reused, got %d logins", logins)
+	}
+
+	// a client of another API KEY performs its own login
+	if _, err := newClient("rotated-apikey").ListBeamTemplates(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if logins := sim.Requests("login"); logins != 2 {
+		t.Fatalf("expected a login of the other API KEY, got %d logins", logins)
+	}
+
+	// a revoked cached token is replaced by the token of a new login
+	session, err := first.Session(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := first.EndSession(ctx, session.auth); err != nil {
+		t.Fatal(err)
+	}
+	c := newClient("apikey")
+	if _, err := c.ListBeamTemplates(ctx); err != nil {
+		t.Fatalf("expected the rejected token to be replaced, got %v", err)
+	}
+	if logins := sim.Requests("login"); logins != 3 {
+		t.Errorf("expected a new login, got %d logins", logins)
+	}
+	if renewed, _ := c.Session(ctx); renewed.auth == session.auth {
+		t.Errorf("expected a new token")
+	}
+}
+
+// shortens the intervals of the attempts to acquire and refresh the lock
+func testTokenCacheLockInterval(t *testing.T, interval time.Duration) {
+	lock, refresh := tokenCacheLockInterval, tokenCacheLockRefresh
+	tokenCacheLockInterval, tokenCacheLockRefresh = interval, interval
+	t.Cleanup(func() { tokenCacheLockInterval, tokenCacheLockRefresh = lock, refresh })
+}
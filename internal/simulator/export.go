@@ -0,0 +1,149 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// API SIMULATOR DOCUMENT EXPORT
+// -------------------------------------------
+// Renders the TOSCA document exported for a
+// simulated BEAM model, describing the tags
+// and the nodes set by the changes of the
+// model, so that the changes performed
+// outside of Terraform may be observed.
+// -------------------------------------------
+
+package simulator
+
+import (
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// the node template of an exported document
+type exportNode struct {
+    name         string
+    values       map[string]string
+    properties   map[string]string
+    capabilities map[string]map[string]string
+}
+
+// returns the keys of the values in order
+func sortedKeys(values map[string]string) []string {
+    keys := make([]string, 0, len(values))
+    for k := range values {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// returns the terms of a change path, without their surrounding spaces
+func pathTerms(p string) []string {
+    terms := strings.Split(p, ".")
+    for i := range terms {
+        terms[i] = strings.TrimSpace(terms[i])
+    }
+    return terms
+}
+
+// ----------------------------------------------------------------------
+// EXPORT DOCUMENT ( model )
+// ----------------------------------------------------------------------
+// Returns the document of the model, being that of its template while
+// unchanged. The document of a changed model describes its tags and
+// numbered nodes, with their type, description, base, properties and
+// capability properties, those of its template not being merged. The
+// nodes missing from the numbering are named after their number.
+// ----------------------------------------------------------------------
+func exportDocument(m *Model) string {
+    if len(m.Data) == 0 {
+        return m.Document
+    }
+    tags := map[string]string{}
+    nodes := map[int]*exportNode{}
+    last := 0
+    for p, v := range m.Data {
+        terms := pathTerms(p)
+        if len(terms) == 2 && terms[0] == "tag" {
+            tags[terms[1]] = v
+            continue
+        }
+        if len(terms) < 3 || len(terms) > 4 || terms[0] != "node" {
+            continue
+        }
+        n, err := strconv.Atoi(terms[1])
+        if err != nil || n < 1 {
+            continue
+        }
+        node, ok := nodes[n]
+        if !ok {
+            node = &exportNode{name: "node" + terms[1], values: map[string]string{}, properties: map[string]string{}, capabilities: map[string]map[string]string{}}
+            nodes[n] = node
+        }
+        if n > last {
+            last = n
+        }
+        switch {
+        case len(terms) == 4:
+            if node.capabilities[terms[2]] == nil {
+                node.capabilities[terms[2]] = map[string]string{}
+            }
+            node.capabilities[terms[2]][terms[3]] = v
+        case terms[2] == "name":
+            node.name = v
+        case terms[2] == "type" || terms[2] == "description" || terms[2] == "base":
+            node.values[terms[2]] = v
+        default:
+            node.properties[terms[2]] = v
+        }
+    }
+
+    var b strings.Builder
+    b.WriteString("tosca_definitions_version: tosca_simple_yaml_1_3\n")
+    if len(tags) > 0 {
+        b.WriteString("metadata:\n")
+        for _, k := range sortedKeys(tags) {
+            b.WriteString("  " + strconv.Quote(k) + ": " + strconv.Quote(tags[k]) + "\n")
+        }
+    }
+    if last == 0 {
+        return b.String()
+    }
+    b.WriteString("topology_template:\n  node_templates:\n")
+    for n := 1; n <= last; n++ {
+        node, ok := nodes[n]
+        if !ok {
+            b.WriteString("    " + strconv.Quote("node"+strconv.Itoa(n)) + ": {}\n")
+            continue
+        }
+        b.WriteString("    " + strconv.Quote(node.name) + ":\n")
+        if t, ok := node.values["type"]; ok {
+            b.WriteString("      type: " + strconv.Quote(t) + "\n")
+        }
+        if d, ok := node.values["description"]; ok {
+            b.WriteString("      description: " + strconv.Quote(d) + "\n")
+        }
+        if len(node.properties) > 0 {
+            b.WriteString("      properties:\n")
+            for _, k := range sortedKeys(node.properties) {
+                b.WriteString("        " + strconv.Quote(k) + ": " + strconv.Quote(node.properties[k]) + "\n")
+            }
+        }
+        if len(node.capabilities) > 0 {
+            b.WriteString("      capabilities:\n")
+            names := make([]string, 0, len(node.capabilities))
+            for c := range node.capabilities {
+                names = append(names, c)
+            }
+            sort.Strings(names)
+            for _, c := range names {
+                b.WriteString("        " + strconv.Quote(c) + ":\n          properties:\n")
+                for _, k := range sortedKeys(node.capabilities[c]) {
+                    b.WriteString("            " + strconv.Quote(k) + ": " + strconv.Quote(node.capabilities[c][k]) + "\n")
+                }
+            }
+        }
+        if base, ok := node.values["base"]; ok {
+            b.WriteString("      requirements:\n        - host: " + strconv.Quote(base) + "\n")
+        }
+    }
+    return b.String()
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import "testing"
+
+func TestExportDocument(t *testing.T) {
+	tests := map[string]struct {
+		data     map[string]string
+		expected string
+	}{
+		"unchanged": {
+			expected: "template document",
+		},
+		"tags": {
+			data: map[string]string{"tag.Title": "Web", "tag . Version": "2"},
+			expected: "tosca_definitions_version: tosca_simple_yaml_1_3\n" +
+				"metadata:\n" +
+				"  \"Title\": \"Web\"\n" +
+				"  \"Version\": \"2\"\n",
+		},
+		"nodes": {
+			data: map[string]string{
+				"node.1.name":          "hwn1",
+				"node.1.type":          "tosca.nodes.Compute",
+				"node.1.host.num_cpus": "2",
+				"node.3.port":          "80",
+				"node.3.base":          "hwn1",
+				"other.1.name":         "ignored",
+			},
+			expected: "tosca_definitions_version: tosca_simple_yaml_1_3\n" +
+				"topology_template:\n" +
+				"  node_templates:\n" +
+				"    \"hwn1\":\n" +
+				"      type: \"tosca.nodes.Compute\"\n" +
+				"      capabilities:\n" +
+				"        \"host\":\n" +
+				"          properties:\n" +
+				"            \"num_cpus\": \"2\"\n" +
+				"    \"node2\": {}\n" +
+				"    \"node3\":\n" +
+				"      properties:\n" +
+				"        \"port\": \"80\"\n" +
+				"      requirements:\n" +
+				"        - host: \"hwn1\"\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := exportDocument(&Model{Document: "template document", Data: test.data})
+			if got != test.expected {
+				t.Errorf("expected the document\n%s\ngot\n%s", test.expected, got)
+			}
+		})
+	}
+}
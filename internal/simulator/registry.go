@@ -0,0 +1,91 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// API SIMULATOR REGISTRY
+// -------------------------------------------
+// The simulators of mock://name provider
+// hosts are shared by name within a process,
+// allowing tests to inspect the simulator of
+// the provider under test, to inject failures
+// and to simulate changes made outside of
+// Terraform. The mock://name hosts are only
+// available when enabled by the environment,
+// so that a production configuration never
+// silently runs against a simulator.
+// -------------------------------------------
+
+package simulator
+
+import (
+    "errors"
+    "os"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+// the scheme of the provider hosts naming a simulator
+const Scheme = "mock://"
+
+// the environment variable enabling the mock://name provider hosts
+const EnableVariable = "ACE_SIMULATOR"
+
+// returned for the mock://name hosts while the simulator is not enabled
+var ErrNotEnabled = errors.New("the simulator hosts are only available when the " + EnableVariable + " environment variable is set to true")
+
+var (
+    registryMu sync.Mutex
+    registry   = map[string]*Simulator{}
+)
+
+// ----------------------------------------------------------------------
+// NAMED ( name )
+// ----------------------------------------------------------------------
+// Returns the started simulator of the name, starting a new simulator,
+// accepting any account and API KEY, on the first use of the name.
+// ----------------------------------------------------------------------
+func Named(name string) *Simulator {
+    registryMu.Lock()
+    defer registryMu.Unlock()
+    s, ok := registry[name]
+    if !ok {
+        s = New("", "")
+        registry[name] = s
+    }
+    s.Start()
+    return s
+}
+
+// returns whether the mock://name hosts are enabled by the environment
+func Enabled() bool {
+    enabled, _ := strconv.ParseBool(os.Getenv(EnableVariable))
+    return enabled
+}
+
+// ----------------------------------------------------------------------
+// HOST ( host )
+// ----------------------------------------------------------------------
+// Returns the URL of the simulator named by a mock://name host, and
+// false for the hosts of the real platform. A mock://name host fails
+// with ErrNotEnabled unless the simulator is enabled by ACE_SIMULATOR.
+// ----------------------------------------------------------------------
+func Host(host string) (string, bool, error) {
+    name, ok := strings.CutPrefix(host, Scheme)
+    if !ok {
+        return "", false, nil
+    }
+    if !Enabled() {
+        return "", true, ErrNotEnabled
+    }
+    return Named(name).URL(), true, nil
+}
+
+// Remove closes and forgets the named simulator.
+func Remove(name string) {
+    registryMu.Lock()
+    s, ok := registry[name]
+    delete(registry, name)
+    registryMu.Unlock()
+    if ok {
+        s.Close()
+    }
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHost(t *testing.T) {
+	tests := map[string]struct {
+		enabled   string
+		host      string
+		simulated bool
+		err       error
+	}{
+		"real host":           {"", "https://www.amenesik.com", false, nil},
+		"real host enabled":   {"true", "https://www.amenesik.com", false, nil},
+		"mock host unset":     {"", "mock://registry", true, ErrNotEnabled},
+		"mock host disabled":  {"false", "mock://registry", true, ErrNotEnabled},
+		"mock host invalid":   {"yes please", "mock://registry", true, ErrNotEnabled},
+		"mock host enabled":   {"true", "mock://registry", true, nil},
+		"mock host enabled 1": {"1", "mock://registry", true, nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnableVariable, test.enabled)
+			defer Remove("registry")
+			url, simulated, err := Host(test.host)
+			if simulated != test.simulated || !errors.Is(err, test.err) {
+				t.Fatalf("expected %t %v, got %t %v", test.simulated, test.err, simulated, err)
+			}
+			if (url != "") != (test.simulated && test.err == nil) {
+				t.Errorf("unexpected URL %q", url)
+			}
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	defer Remove("shared")
+	s := Named("shared")
+	if s.URL() == "" {
+		t.Fatal("expected a started simulator")
+	}
+	if Named("shared") != s {
+		t.Error("expected the simulator to be shared by name")
+	}
+	t.Setenv(EnableVariable, "true")
+	if url, _, _ := Host("mock://shared"); url != s.URL() {
+		t.Errorf("expected the URL of the shared simulator, got %q", url)
+	}
+	Remove("shared")
+	if s.URL() != "" {
+		t.Error("expected the removed simulator to be closed")
+	}
+}
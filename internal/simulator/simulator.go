@@ -0,0 +1,631 @@
+// -------------------------------------------
+// AMENESIK CLOUD ENGINE (ACE)
+// API SIMULATOR
+// -------------------------------------------
+// An in-process implementation of the ACE
+// api.php protocol, running as an httptest
+// server, allowing the provider resources to
+// be developed and tested without a live ACE
+// host. BEAM models are cloned and changed,
+// and their instances pass through the
+// transitional states of the real platform
+// before reaching their target state.
+// -------------------------------------------
+
+// Package simulator implements an in-process ACE API server.
+package simulator
+
+import (
+    "crypto/rand"
+    "encoding/hex"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "sort"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// the duration of instance transitions of new simulators
+var DefaultDelay = 2 * time.Second
+
+// the lifetime of the tokens issued by the login action
+var TokenLifetime = time.Hour
+
+// ---------------------------------
+// ACE instance transition
+// ---------------------------------
+type transition struct {
+    from   []string
+    during string
+    to     string
+}
+
+// the instance life cycle actions, an instance not existing being "none"
+var transitions = map[string]transition{
+    "create":  {from: []string{"none"}, during: "creating", to: "created"},
+    "start":   {from: []string{"created"}, during: "starting", to: "started"},
+    "stop":    {from: []string{"started", "suspended"}, during: "stopping", to: "created"},
+    "suspend": {from: []string{"started"}, during: "suspending", to: "suspended"},
+    "resume":  {from: []string{"suspended"}, during: "resuming", to: "started"},
+    "drop":    {from: []string{"created", "failed"}, during: "deleting", to: "none"},
+}
+
+// ---------------------------------
+// ACE BEAM Application instance
+// ---------------------------------
+type Instance struct {
+    Status string
+    Lock   bool
+    Param  string
+    target string
+    until  time.Time
+}
+
+// ---------------------------------
+// ACE BEAM model
+// ---------------------------------
+type Model struct {
+    Name     string
+    Template string
+    Program  string
+    Domain   string
+    Region   string
+    Provider string
+    Document string
+    Data     map[string]string
+    Instance *Instance
+}
+
+// ---------------------------------
+// ACE API simulator
+// ---------------------------------
+type Simulator struct {
+    mu        sync.Mutex
+    server    *httptest.Server
+    account   string
+    apikey    string
+    delay     time.Duration
+    tokens    map[string]time.Time
+    templates map[string]string
+    probes    map[string]bool
+    models    map[string]*Model
+    failures  map[string][]int
+    broken    map[string]int
+    requests  map[string]int
+    serial    int
+}
+
+// ----------------------------------------------------------------------
+// NEW ( account, apikey )
+// ----------------------------------------------------------------------
+// Returns a simulator accepting the login of the account with the API
+// KEY, any account and API KEY being accepted when these are empty. The
+// simulator serves requests once started.
+// ----------------------------------------------------------------------
+func New(account string, apikey string) *Simulator {
+    return &Simulator{
+        account:   account,
+        apikey:    apikey,
+        delay:     DefaultDelay,
+        tokens:    map[string]time.Time{},
+        templates: map[string]string{},
+        probes:    map[string]bool{},
+        models:    map[string]*Model{},
+        failures:  map[string][]int{},
+        broken:    map[string]int{},
+        requests:  map[string]int{},
+    }
+}
+
+// Start starts the simulator server on a local address.
+func (s *Simulator) Start() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if s.server == nil {
+        s.server = httptest.NewServer(s)
+    }
+}
+
+// Close shuts the simulator server down.
+func (s *Simulator) Close() {
+    s.mu.Lock()
+    server := s.server
+    s.server = nil
+    s.mu.Unlock()
+    if server != nil {
+        server.Close()
+    }
+}
+
+// URL returns the base URL of the started simulator, such as http://127.0.0.1:1234.
+func (s *Simulator) URL() string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if s.server == nil {
+        return ""
+    }
+    return s.server.URL
+}
+
+// SetDelay sets the duration of the subsequent instance transitions.
+func (s *Simulator) SetDelay(d time.Duration) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.delay = d
+}
+
+// AddTemplate adds a BEAM template, with its document, to the catalogue.
+func (s *Simulator) AddTemplate(name string, document string) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.templates[name] = document
+}
+
+// AddProbe adds the named probe definition to those of the account.
+func (s *Simulator) AddProbe(name string) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.probes[name] = true
+}
+
+// Reset removes all the models, instances, tokens and injected failures.
+func (s *Simulator) Reset() {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.tokens = map[string]time.Time{}
+    s.models = map[string]*Model{}
+    s.failures = map[string][]int{}
+    s.broken = map[string]int{}
+    s.requests = map[string]int{}
+}
+
+// ----------------------------------------------------------------------
+// FAIL ( action, status )
+// ----------------------------------------------------------------------
+// Injects a failure: the next request of the action, such as "start",
+// is answered with the HTTP status, such as 500, without effect.
+// ----------------------------------------------------------------------
+func (s *Simulator) Fail(action string, status int) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.failures[action] = append(s.failures[action], status)
+}
+
+// ----------------------------------------------------------------------
+// BREAK ( action )
+// ----------------------------------------------------------------------
+// Injects a failed transition: the next instance transition of the
+// action, such as "create", is accepted but ends in the "failed" state.
+// ----------------------------------------------------------------------
+func (s *Simulator) Break(action string) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.broken[action]++
+}
+
+// Requests returns the number of requests of the action received.
+func (s *Simulator) Requests(action string) int {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    return s.requests[action]
+}
+
+// Model returns a copy of the named model and of its instance.
+func (s *Simulator) Model(name string) (Model, bool) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    m, ok := s.models[name]
+    if !ok {
+        return Model{}, false
+    }
+    s.advance(m)
+    c := *m
+    c.Data = map[string]string{}
+    for k, v := range m.Data {
+        c.Data[k] = v
+    }
+    if m.Instance != nil {
+        i := *m.Instance
+        c.Instance = &i
+    }
+    return c, true
+}
+
+// Models returns the names of the models.
+func (s *Simulator) Models() []string {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    var names []string
+    for name := range s.models {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// ----------------------------------------------------------------------
+// SET MODEL ( model )
+// ----------------------------------------------------------------------
+// Adds or replaces a model, with its instance, such as to simulate the
+// changes performed outside of Terraform.
+// ----------------------------------------------------------------------
+func (s *Simulator) SetModel(m Model) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    if m.Data == nil {
+        m.Data = map[string]string{}
+    }
+    if m.Instance != nil {
+        i := *m.Instance
+        m.Instance = &i
+    }
+    s.models[m.Name] = &m
+}
+
+// SetStatus sets the status of the instance of the model outside of Terraform.
+func (s *Simulator) SetStatus(name string, status string) bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    m, ok := s.models[name]
+    if !ok {
+        return false
+    }
+    if status == "none" {
+        m.Instance = nil
+        return true
+    }
+    if m.Instance == nil {
+        m.Instance = &Instance{}
+    }
+    m.Instance.Status = status
+    m.Instance.target = ""
+    return true
+}
+
+// DeleteModel deletes the model, with its instance, outside of Terraform.
+func (s *Simulator) DeleteModel(name string) bool {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    _, ok := s.models[name]
+    delete(s.models, name)
+    return ok
+}
+
+// ----------------------------------------------------------------------
+// NEW MODEL NAME ( template, program )
+// ----------------------------------------------------------------------
+// Returns the name of a model cloned from the template for the program,
+// which replaces the terminal "template" of the template name. Models of
+// other templates are given serial names that clients cannot derive.
+// ----------------------------------------------------------------------
+func (s *Simulator) newModelName(template string, program string) string {
+    if strings.HasSuffix(template, "template") {
+        return strings.TrimSuffix(template, "template") + program
+    }
+    s.serial++
+    return "beam" + strconv.Itoa(s.serial)
+}
+
+// returns the model cloned from the template of the request for its program
+func (s *Simulator) find(req map[string]string) (*Model, bool) {
+    for _, m := range s.models {
+        if m.Template == req["template"] && m.Program == req["program"] {
+            return m, true
+        }
+    }
+    return nil, false
+}
+
+// completes the transition of the instance once its delay has elapsed
+func (s *Simulator) advance(m *Model) {
+    i := m.Instance
+    if i == nil || i.target == "" || time.Now().Before(i.until) {
+        return
+    }
+    if i.target == "none" {
+        m.Instance = nil
+        return
+    }
+    i.Status = i.target
+    i.target = ""
+}
+
+// returns a new random token
+func newToken() string {
+    b := make([]byte, 16)
+    rand.Read(b)
+    return hex.EncodeToString(b)
+}
+
+// writes the flat JSON object of string values expected by the ACE client
+func reply(w http.ResponseWriter, values map[string]string) {
+    w.Header().Set("Content-Type", "application/json")
+    body, _ := json.Marshal(values)
+    w.Write(body)
+}
+
+// writes the JSON response with a result member
+func replyResult(w http.ResponseWriter, result interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    body, _ := json.Marshal(map[string]interface{}{"status": "200", "result": result})
+    w.Write(body)
+}
+
+// ----------------------------------------------------------------------
+// SERVE HTTP ( request )
+// ----------------------------------------------------------------------
+// Handles the JSON action requests of the ACE api.php protocol.
+// ----------------------------------------------------------------------
+func (s *Simulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    var req map[string]string
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, "invalid request", http.StatusBadRequest)
+        return
+    }
+    action := req["action"]
+
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    s.requests[action]++
+
+    // the injected failures are answered first
+    if statuses := s.failures[action]; len(statuses) > 0 {
+        s.failures[action] = statuses[1:]
+        http.Error(w, "injected failure", statuses[0])
+        return
+    }
+
+    if action == "login" {
+        s.login(w, req)
+        return
+    }
+
+    token := req["auth"]
+    if token == "" {
+        token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+    }
+    if expiry, ok := s.tokens[token]; !ok || time.Now().After(expiry) {
+        http.Error(w, "unauthorized", http.StatusUnauthorized)
+        return
+    }
+
+    switch action {
+    case "logout":
+        delete(s.tokens, token)
+        reply(w, map[string]string{"status": "200"})
+    case "whoami":
+        replyResult(w, map[string]string{
+            "account": s.accountOf(req),
+            "user":    s.accountOf(req),
+            "role":    "admin",
+            "expires": strconv.FormatInt(s.tokens[token].Unix(), 10),
+        })
+    case "list":
+        s.list(w, req)
+    case "export":
+        s.export(w, req)
+    case "clone", "change", "delete":
+        s.model(w, action, req)
+    case "lock", "unlock", "status":
+        s.instance(w, action, req)
+    default:
+        if _, ok := transitions[action]; ok {
+            s.transition(w, action, req)
+            return
+        }
+        http.Error(w, "unsupported action", http.StatusBadRequest)
+    }
+}
+
+// returns the account of the request
+func (s *Simulator) accountOf(req map[string]string) string {
+    if s.account != "" {
+        return s.account
+    }
+    return req["account"]
+}
+
+// performs the login action of the account
+func (s *Simulator) login(w http.ResponseWriter, req map[string]string) {
+    if (s.account != "" && req["user"] != s.account) || (s.apikey != "" && req["secret"] != s.apikey) {
+        reply(w, map[string]string{"status": "403", "auth": "none"})
+        return
+    }
+    token := newToken()
+    expiry := time.Now().Add(TokenLifetime)
+    s.tokens[token] = expiry
+    reply(w, map[string]string{
+        "status":  "200",
+        "auth":    token,
+        "account": req["user"],
+        "user":    req["user"],
+        "role":    "admin",
+        "expires": strconv.FormatInt(expiry.Unix(), 10),
+    })
+}
+
+// lists the templates of the catalogue or the probe definitions of the account
+func (s *Simulator) list(w http.ResponseWriter, req map[string]string) {
+    if req["subject"] == "probe" {
+        type probe struct {
+            Name string `json:"name"`
+        }
+        probes := []probe{}
+        for name := range s.probes {
+            probes = append(probes, probe{Name: name})
+        }
+        sort.Slice(probes, func(i, j int) bool { return probes[i].Name < probes[j].Name })
+        replyResult(w, probes)
+        return
+    }
+    if req["subject"] != "beam" {
+        replyResult(w, []interface{}{})
+        return
+    }
+    type template struct {
+        Name string            `json:"name"`
+        Tags map[string]string `json:"tags"`
+    }
+    templates := []template{}
+    for name := range s.templates {
+        templates = append(templates, template{Name: name, Tags: map[string]string{}})
+    }
+    sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+    replyResult(w, templates)
+}
+
+// exports the document of a model, describing its changes
+func (s *Simulator) export(w http.ResponseWriter, req map[string]string) {
+    m, ok := s.find(req)
+    if !ok {
+        reply(w, map[string]string{"status": "404", "message": "model not found"})
+        return
+    }
+    replyResult(w, exportDocument(m))
+}
+
+// performs the model actions
+func (s *Simulator) model(w http.ResponseWriter, action string, req map[string]string) {
+    m, ok := s.find(req)
+    switch action {
+    case "clone":
+        if ok {
+            http.Error(w, "model exists", http.StatusConflict)
+            return
+        }
+        document, ok := s.templates[req["template"]]
+        if !ok && len(s.templates) > 0 {
+            http.Error(w, "template not found", http.StatusNotFound)
+            return
+        }
+        name := s.newModelName(req["template"], req["program"])
+        if _, exists := s.models[name]; exists {
+            http.Error(w, "model exists", http.StatusConflict)
+            return
+        }
+        s.models[name] = &Model{
+            Name:     name,
+            Template: req["template"],
+            Program:  req["program"],
+            Domain:   req["domain"],
+            Region:   req["region"],
+            Provider: req["provider"],
+            Document: document,
+            Data:     map[string]string{},
+        }
+        reply(w, map[string]string{"status": "200", "id": name})
+    case "change":
+        if !ok {
+            http.Error(w, "model not found", http.StatusNotFound)
+            return
+        }
+        path, value, found := strings.Cut(req["data"], ":")
+        if !found {
+            http.Error(w, "invalid change", http.StatusBadRequest)
+            return
+        }
+        m.Data[path] = value
+        reply(w, map[string]string{"status": "200", "id": m.Name})
+    case "delete":
+        if !ok {
+            http.Error(w, "model not found", http.StatusNotFound)
+            return
+        }
+        s.advance(m)
+        if m.Instance != nil {
+            http.Error(w, "model instance exists", http.StatusConflict)
+            return
+        }
+        delete(s.models, m.Name)
+        reply(w, map[string]string{"status": "200", "id": m.Name})
+    }
+}
+
+// performs the lock, unlock and status actions of an instance
+func (s *Simulator) instance(w http.ResponseWriter, action string, req map[string]string) {
+    m, ok := s.find(req)
+    if ok {
+        s.advance(m)
+    }
+    if !ok || m.Instance == nil {
+        if action == "status" {
+            reply(w, map[string]string{"status": "none"})
+            return
+        }
+        http.Error(w, "instance not found", http.StatusNotFound)
+        return
+    }
+    name := m.Name
+    i := m.Instance
+    switch action {
+    case "lock":
+        i.Lock = true
+        reply(w, map[string]string{"status": "locked", "id": name})
+    case "unlock":
+        i.Lock = false
+        reply(w, map[string]string{"status": i.Status, "id": name})
+    case "status":
+        lock := "no"
+        if i.Lock {
+            lock = "yes"
+        }
+        reply(w, map[string]string{
+            "status":   i.Status,
+            "id":       name,
+            "lock":     lock,
+            "domain":   m.Domain,
+            "provider": m.Provider,
+            "region":   m.Region,
+        })
+    }
+}
+
+// starts the transition of an instance life cycle action
+func (s *Simulator) transition(w http.ResponseWriter, action string, req map[string]string) {
+    t := transitions[action]
+    m, ok := s.find(req)
+    if !ok {
+        http.Error(w, "model not found", http.StatusNotFound)
+        return
+    }
+    name := m.Name
+    s.advance(m)
+    status := "none"
+    if m.Instance != nil {
+        status = m.Instance.Status
+        if m.Instance.Lock {
+            http.Error(w, "instance locked", http.StatusConflict)
+            return
+        }
+        if m.Instance.target != "" {
+            http.Error(w, "instance busy", http.StatusConflict)
+            return
+        }
+    }
+    allowed := false
+    for _, from := range t.from {
+        allowed = allowed || from == status
+    }
+    if !allowed {
+        http.Error(w, "invalid instance status "+status, http.StatusConflict)
+        return
+    }
+    if m.Instance == nil {
+        m.Instance = &Instance{Param: req["param"]}
+    }
+    m.Instance.Status = t.during
+    m.Instance.target = t.to
+    m.Instance.until = time.Now().Add(s.delay)
+    if s.broken[action] > 0 {
+        s.broken[action]--
+        m.Instance.target = "failed"
+    }
+    reply(w, map[string]string{"status": t.during, "id": name})
+}
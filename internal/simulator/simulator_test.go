@@ -0,0 +1,343 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testSimulator returns a started simulator of the test account, closed
+// at the end of the test.
+func testSimulator(t *testing.T) *Simulator {
+	t.Helper()
+	s := New("test", "apikey")
+	s.Start()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// testPost posts the action request to the simulator, returning the HTTP
+// status and the decoded response, which is nil when not JSON.
+func testPost(t *testing.T, s *Simulator, req map[string]string) (int, map[string]interface{}) {
+	t.Helper()
+	body, _ := json.Marshal(req)
+	resp, err := http.Post(s.URL(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var values map[string]interface{}
+	if json.NewDecoder(resp.Body).Decode(&values) != nil {
+		values = nil
+	}
+	return resp.StatusCode, values
+}
+
+// testLogin returns the token of the login of the test account.
+func testLogin(t *testing.T, s *Simulator) string {
+	t.Helper()
+	status, values := testPost(t, s, map[string]string{"action": "login", "user": "test", "secret": "apikey"})
+	token, _ := values["auth"].(string)
+	if status != http.StatusOK || values["status"] != "200" || token == "" {
+		t.Fatalf("unexpected login %d %v", status, values)
+	}
+	return token
+}
+
+// testAction posts the action of the webstore model with the token.
+func testAction(t *testing.T, s *Simulator, token string, action string, fields ...string) (int, map[string]interface{}) {
+	t.Helper()
+	req := map[string]string{"action": action, "auth": token, "subject": "beam", "template": "webtemplate", "program": "store"}
+	for i := 0; i+1 < len(fields); i += 2 {
+		req[fields[i]] = fields[i+1]
+	}
+	return testPost(t, s, req)
+}
+
+// testStatus returns the status of the instance of the webstore model.
+func testStatus(t *testing.T, s *Simulator, token string) string {
+	t.Helper()
+	status, values := testAction(t, s, token, "status")
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status response %d", status)
+	}
+	return values["status"].(string)
+}
+
+func TestSimulatorLogin(t *testing.T) {
+	s := testSimulator(t)
+	tests := map[string]struct {
+		user     string
+		secret   string
+		accepted bool
+	}{
+		"account":       {"test", "apikey", true},
+		"wrong apikey":  {"test", "wrong", false},
+		"wrong account": {"other", "apikey", false},
+		"no apikey":     {"test", "", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, values := testPost(t, s, map[string]string{"action": "login", "user": test.user, "secret": test.secret})
+			if status != http.StatusOK {
+				t.Fatalf("unexpected HTTP status %d", status)
+			}
+			if accepted := values["status"] == "200" && values["auth"] != "none"; accepted != test.accepted {
+				t.Errorf("expected the login accepted %t, got %v", test.accepted, values)
+			}
+		})
+	}
+}
+
+func TestSimulatorAnyAccount(t *testing.T) {
+	s := New("", "")
+	s.Start()
+	defer s.Close()
+	_, values := testPost(t, s, map[string]string{"action": "login", "user": "any", "secret": "key"})
+	if values["status"] != "200" || values["account"] != "any" {
+		t.Errorf("expected any account to be accepted, got %v", values)
+	}
+}
+
+func TestSimulatorToken(t *testing.T) {
+	s := testSimulator(t)
+	if status, _ := testAction(t, s, "", "status"); status != http.StatusUnauthorized {
+		t.Errorf("expected a request without token to be unauthorized, got %d", status)
+	}
+	if status, _ := testAction(t, s, "unknown", "status"); status != http.StatusUnauthorized {
+		t.Errorf("expected a request of an unknown token to be unauthorized, got %d", status)
+	}
+	token := testLogin(t, s)
+	status, values := testPost(t, s, map[string]string{"action": "whoami", "auth": token})
+	if result, _ := values["result"].(map[string]interface{}); status != http.StatusOK || result["account"] != "test" {
+		t.Errorf("unexpected whoami %d %v", status, values)
+	}
+	if status, _ := testPost(t, s, map[string]string{"action": "logout", "auth": token}); status != http.StatusOK {
+		t.Errorf("unexpected logout status %d", status)
+	}
+	if status, _ := testAction(t, s, token, "status"); status != http.StatusUnauthorized {
+		t.Errorf("expected the revoked token to be unauthorized, got %d", status)
+	}
+}
+
+func TestSimulatorModel(t *testing.T) {
+	s := testSimulator(t)
+	token := testLogin(t, s)
+
+	// clone testing, the model being named after the template
+	if status, values := testAction(t, s, token, "clone", "domain", "example.com"); status != http.StatusOK || values["id"] != "webstore" {
+		t.Fatalf("unexpected clone %d %v", status, values)
+	}
+	if status, _ := testAction(t, s, token, "clone"); status != http.StatusConflict {
+		t.Errorf("expected the clone of an existing model to conflict, got %d", status)
+	}
+
+	// change testing
+	if status, _ := testAction(t, s, token, "change", "data", "node.1.host.num_cpus:2"); status != http.StatusOK {
+		t.Fatalf("unexpected change status %d", status)
+	}
+	if status, _ := testAction(t, s, token, "change", "data", "invalid"); status != http.StatusBadRequest {
+		t.Errorf("expected an invalid change to be refused, got %d", status)
+	}
+	m, ok := s.Model("webstore")
+	if !ok || m.Domain != "example.com" || !reflect.DeepEqual(m.Data, map[string]string{"node.1.host.num_cpus": "2"}) {
+		t.Errorf("unexpected model %v", m)
+	}
+
+	// delete testing, refused while the model has an instance
+	testAction(t, s, token, "create")
+	if status, _ := testAction(t, s, token, "delete"); status != http.StatusConflict {
+		t.Errorf("expected the delete of a model with an instance to conflict, got %d", status)
+	}
+	s.SetStatus("webstore", "none")
+	if status, _ := testAction(t, s, token, "delete"); status != http.StatusOK {
+		t.Errorf("unexpected delete status %d", status)
+	}
+	if _, ok := s.Model("webstore"); ok {
+		t.Error("expected the model to be deleted")
+	}
+	if status, _ := testAction(t, s, token, "change", "data", "tag.Title:Web"); status != http.StatusNotFound {
+		t.Errorf("expected the change of a deleted model to fail, got %d", status)
+	}
+}
+
+func TestSimulatorModelName(t *testing.T) {
+	s := testSimulator(t)
+	token := testLogin(t, s)
+	_, values := testPost(t, s, map[string]string{"action": "clone", "auth": token, "template": "web", "program": "store"})
+	if values["id"] != "beam1" {
+		t.Errorf("expected a serial model name, got %v", values)
+	}
+	s.AddTemplate("webtemplate", "tosca_definitions_version: tosca_simple_yaml_1_3\n")
+	if status, _ := testPost(t, s, map[string]string{"action": "clone", "auth": token, "template": "other", "program": "store"}); status != http.StatusNotFound {
+		t.Errorf("expected the clone of an unknown template to fail, got %d", status)
+	}
+}
+
+func TestSimulatorTransitions(t *testing.T) {
+	s := testSimulator(t)
+	s.SetDelay(0)
+	token := testLogin(t, s)
+	testAction(t, s, token, "clone")
+	if got := testStatus(t, s, token); got != "none" {
+		t.Fatalf("expected no instance, got %q", got)
+	}
+
+	steps := []struct {
+		action   string
+		expected int
+		status   string
+	}{
+		{"start", http.StatusConflict, "none"},
+		{"create", http.StatusOK, "created"},
+		{"create", http.StatusConflict, "created"},
+		{"start", http.StatusOK, "started"},
+		{"suspend", http.StatusOK, "suspended"},
+		{"resume", http.StatusOK, "started"},
+		{"drop", http.StatusConflict, "started"},
+		{"stop", http.StatusOK, "created"},
+		{"drop", http.StatusOK, "none"},
+	}
+	for _, step := range steps {
+		if status, values := testAction(t, s, token, step.action, "param", "none"); status != step.expected {
+			t.Fatalf("expected the %s status %d, got %d %v", step.action, step.expected, status, values)
+		}
+		if got := testStatus(t, s, token); got != step.status {
+			t.Fatalf("expected the status %q after %s, got %q", step.status, step.action, got)
+		}
+	}
+}
+
+func TestSimulatorTransitionDelay(t *testing.T) {
+	s := testSimulator(t)
+	s.SetDelay(time.Hour)
+	token := testLogin(t, s)
+	testAction(t, s, token, "clone")
+	if _, values := testAction(t, s, token, "create"); values["status"] != "creating" {
+		t.Fatalf("expected a creating instance, got %v", values)
+	}
+	if got := testStatus(t, s, token); got != "creating" {
+		t.Errorf("expected the instance to be creating, got %q", got)
+	}
+	if status, _ := testAction(t, s, token, "start"); status != http.StatusConflict {
+		t.Errorf("expected a busy instance to refuse the start, got %d", status)
+	}
+}
+
+func TestSimulatorLock(t *testing.T) {
+	s := testSimulator(t)
+	s.SetDelay(0)
+	token := testLogin(t, s)
+	testAction(t, s, token, "clone")
+	if status, _ := testAction(t, s, token, "lock"); status != http.StatusNotFound {
+		t.Errorf("expected the lock of a missing instance to fail, got %d", status)
+	}
+	testAction(t, s, token, "create")
+	if _, values := testAction(t, s, token, "lock"); values["status"] != "locked" {
+		t.Errorf("unexpected lock %v", values)
+	}
+	if _, values := testAction(t, s, token, "status"); values["lock"] != "yes" {
+		t.Errorf("expected a locked instance, got %v", values)
+	}
+	if status, _ := testAction(t, s, token, "start"); status != http.StatusConflict {
+		t.Errorf("expected a locked instance to refuse the start, got %d", status)
+	}
+	if _, values := testAction(t, s, token, "unlock"); values["status"] != "created" {
+		t.Errorf("unexpected unlock %v", values)
+	}
+	if status, _ := testAction(t, s, token, "start"); status != http.StatusOK {
+		t.Errorf("expected an unlocked instance to start, got %d", status)
+	}
+	if got := testStatus(t, s, token); got != "started" {
+		t.Errorf("expected a started instance, got %q", got)
+	}
+}
+
+func TestSimulatorFail(t *testing.T) {
+	s := testSimulator(t)
+	s.SetDelay(0)
+	token := testLogin(t, s)
+	testAction(t, s, token, "clone")
+	s.Fail("create", http.StatusInternalServerError)
+	if status, _ := testAction(t, s, token, "create"); status != http.StatusInternalServerError {
+		t.Errorf("expected the injected failure, got %d", status)
+	}
+	if got := testStatus(t, s, token); got != "none" {
+		t.Errorf("expected the failed request to have no effect, got %q", got)
+	}
+	if status, _ := testAction(t, s, token, "create"); status != http.StatusOK {
+		t.Errorf("expected the next request to succeed, got %d", status)
+	}
+	if n := s.Requests("create"); n != 2 {
+		t.Errorf("expected 2 create requests, got %d", n)
+	}
+}
+
+func TestSimulatorBreak(t *testing.T) {
+	s := testSimulator(t)
+	s.SetDelay(0)
+	token := testLogin(t, s)
+	testAction(t, s, token, "clone")
+	s.Break("create")
+	if status, values := testAction(t, s, token, "create"); status != http.StatusOK || values["status"] != "creating" {
+		t.Fatalf("expected the broken transition to be accepted, got %d %v", status, values)
+	}
+	if got := testStatus(t, s, token); got != "failed" {
+		t.Errorf("expected a failed instance, got %q", got)
+	}
+	if status, _ := testAction(t, s, token, "drop"); status != http.StatusOK {
+		t.Errorf("expected a failed instance to be dropped, got %d", status)
+	}
+	if got := testStatus(t, s, token); got != "none" {
+		t.Errorf("expected the instance to be dropped, got %q", got)
+	}
+}
+
+func TestSimulatorList(t *testing.T) {
+	s := testSimulator(t)
+	s.AddTemplate("webtemplate", "")
+	s.AddTemplate("apptemplate", "")
+	s.AddProbe("memory")
+	s.AddProbe("disk")
+	token := testLogin(t, s)
+	names := func(subject string) []string {
+		_, values := testPost(t, s, map[string]string{"action": "list", "auth": token, "subject": subject})
+		names := []string{}
+		for _, item := range values["result"].([]interface{}) {
+			names = append(names, item.(map[string]interface{})["name"].(string))
+		}
+		return names
+	}
+	if got := names("beam"); !reflect.DeepEqual(got, []string{"apptemplate", "webtemplate"}) {
+		t.Errorf("unexpected templates %v", got)
+	}
+	if got := names("probe"); !reflect.DeepEqual(got, []string{"disk", "memory"}) {
+		t.Errorf("unexpected probes %v", got)
+	}
+	if got := names("type"); len(got) != 0 {
+		t.Errorf("expected no node types, got %v", got)
+	}
+}
+
+func TestSimulatorExport(t *testing.T) {
+	s := testSimulator(t)
+	s.AddTemplate("webtemplate", "tosca_definitions_version: tosca_simple_yaml_1_3\n")
+	token := testLogin(t, s)
+	if _, values := testAction(t, s, token, "export"); values["status"] != "404" {
+		t.Errorf("expected the export of a missing model to fail, got %v", values)
+	}
+	testAction(t, s, token, "clone")
+	if _, values := testAction(t, s, token, "export"); values["result"] != "tosca_definitions_version: tosca_simple_yaml_1_3\n" {
+		t.Errorf("expected the template document, got %v", values)
+	}
+	testAction(t, s, token, "change", "data", "tag.Title:Web")
+	if _, values := testAction(t, s, token, "export"); values["result"] != exportDocument(&Model{Data: map[string]string{"tag.Title": "Web"}}) {
+		t.Errorf("expected the changed document, got %v", values)
+	}
+}